@@ -0,0 +1,630 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: leaderboard/v1/leaderboard.proto
+
+package leaderboardv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AuthService_Register_FullMethodName = "/leaderboard.v1.AuthService/Register"
+	AuthService_Login_FullMethodName    = "/leaderboard.v1.AuthService/Login"
+)
+
+// AuthServiceClient is the client API for AuthService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AuthService issues the JWTs accepted by both the HTTP and the gRPC API.
+type AuthServiceClient interface {
+	// Register creates a player account.
+	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error)
+	// Login exchanges credentials for an access and a refresh token.
+	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+}
+
+type authServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAuthServiceClient(cc grpc.ClientConnInterface) AuthServiceClient {
+	return &authServiceClient{cc}
+}
+
+func (c *authServiceClient) Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegisterResponse)
+	err := c.cc.Invoke(ctx, AuthService_Register_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, AuthService_Login_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuthServiceServer is the server API for AuthService service.
+// All implementations must embed UnimplementedAuthServiceServer
+// for forward compatibility.
+//
+// AuthService issues the JWTs accepted by both the HTTP and the gRPC API.
+type AuthServiceServer interface {
+	// Register creates a player account.
+	Register(context.Context, *RegisterRequest) (*RegisterResponse, error)
+	// Login exchanges credentials for an access and a refresh token.
+	Login(context.Context, *LoginRequest) (*LoginResponse, error)
+	mustEmbedUnimplementedAuthServiceServer()
+}
+
+// UnimplementedAuthServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAuthServiceServer struct{}
+
+func (UnimplementedAuthServiceServer) Register(context.Context, *RegisterRequest) (*RegisterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
+}
+func (UnimplementedAuthServiceServer) Login(context.Context, *LoginRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
+func (UnimplementedAuthServiceServer) mustEmbedUnimplementedAuthServiceServer() {}
+func (UnimplementedAuthServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeAuthServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AuthServiceServer will
+// result in compilation errors.
+type UnsafeAuthServiceServer interface {
+	mustEmbedUnimplementedAuthServiceServer()
+}
+
+func RegisterAuthServiceServer(s grpc.ServiceRegistrar, srv AuthServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAuthServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AuthService_ServiceDesc, srv)
+}
+
+func _AuthService_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).Register(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_Register_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).Register(ctx, req.(*RegisterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_Login_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).Login(ctx, req.(*LoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AuthService_ServiceDesc is the grpc.ServiceDesc for AuthService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AuthService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "leaderboard.v1.AuthService",
+	HandlerType: (*AuthServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Register",
+			Handler:    _AuthService_Register_Handler,
+		},
+		{
+			MethodName: "Login",
+			Handler:    _AuthService_Login_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "leaderboard/v1/leaderboard.proto",
+}
+
+const (
+	ScoreService_SubmitScore_FullMethodName  = "/leaderboard.v1.ScoreService/SubmitScore"
+	ScoreService_SubmitScores_FullMethodName = "/leaderboard.v1.ScoreService/SubmitScores"
+	ScoreService_StreamScores_FullMethodName = "/leaderboard.v1.ScoreService/StreamScores"
+)
+
+// ScoreServiceClient is the client API for ScoreService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ScoreService records the scores of the authenticated user. Calls need an
+// "authorization: Bearer <access token>" metadata entry.
+type ScoreServiceClient interface {
+	// SubmitScore records one score, with the same checks as POST /api/score/submit.
+	SubmitScore(ctx context.Context, in *SubmitScoreRequest, opts ...grpc.CallOption) (*SubmitScoreResponse, error)
+	// SubmitScores records up to 100 scores; each one is accepted or rejected on its own.
+	SubmitScores(ctx context.Context, in *SubmitScoresRequest, opts ...grpc.CallOption) (*SubmitScoresResponse, error)
+	// StreamScores records the scores sent on the stream and answers each one
+	// with its result, in order.
+	StreamScores(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[SubmitScoreRequest, SubmitScoreResult], error)
+}
+
+type scoreServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewScoreServiceClient(cc grpc.ClientConnInterface) ScoreServiceClient {
+	return &scoreServiceClient{cc}
+}
+
+func (c *scoreServiceClient) SubmitScore(ctx context.Context, in *SubmitScoreRequest, opts ...grpc.CallOption) (*SubmitScoreResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitScoreResponse)
+	err := c.cc.Invoke(ctx, ScoreService_SubmitScore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scoreServiceClient) SubmitScores(ctx context.Context, in *SubmitScoresRequest, opts ...grpc.CallOption) (*SubmitScoresResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitScoresResponse)
+	err := c.cc.Invoke(ctx, ScoreService_SubmitScores_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scoreServiceClient) StreamScores(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[SubmitScoreRequest, SubmitScoreResult], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ScoreService_ServiceDesc.Streams[0], ScoreService_StreamScores_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubmitScoreRequest, SubmitScoreResult]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ScoreService_StreamScoresClient = grpc.BidiStreamingClient[SubmitScoreRequest, SubmitScoreResult]
+
+// ScoreServiceServer is the server API for ScoreService service.
+// All implementations must embed UnimplementedScoreServiceServer
+// for forward compatibility.
+//
+// ScoreService records the scores of the authenticated user. Calls need an
+// "authorization: Bearer <access token>" metadata entry.
+type ScoreServiceServer interface {
+	// SubmitScore records one score, with the same checks as POST /api/score/submit.
+	SubmitScore(context.Context, *SubmitScoreRequest) (*SubmitScoreResponse, error)
+	// SubmitScores records up to 100 scores; each one is accepted or rejected on its own.
+	SubmitScores(context.Context, *SubmitScoresRequest) (*SubmitScoresResponse, error)
+	// StreamScores records the scores sent on the stream and answers each one
+	// with its result, in order.
+	StreamScores(grpc.BidiStreamingServer[SubmitScoreRequest, SubmitScoreResult]) error
+	mustEmbedUnimplementedScoreServiceServer()
+}
+
+// UnimplementedScoreServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedScoreServiceServer struct{}
+
+func (UnimplementedScoreServiceServer) SubmitScore(context.Context, *SubmitScoreRequest) (*SubmitScoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitScore not implemented")
+}
+func (UnimplementedScoreServiceServer) SubmitScores(context.Context, *SubmitScoresRequest) (*SubmitScoresResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitScores not implemented")
+}
+func (UnimplementedScoreServiceServer) StreamScores(grpc.BidiStreamingServer[SubmitScoreRequest, SubmitScoreResult]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamScores not implemented")
+}
+func (UnimplementedScoreServiceServer) mustEmbedUnimplementedScoreServiceServer() {}
+func (UnimplementedScoreServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeScoreServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ScoreServiceServer will
+// result in compilation errors.
+type UnsafeScoreServiceServer interface {
+	mustEmbedUnimplementedScoreServiceServer()
+}
+
+func RegisterScoreServiceServer(s grpc.ServiceRegistrar, srv ScoreServiceServer) {
+	// If the following call pancis, it indicates UnimplementedScoreServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ScoreService_ServiceDesc, srv)
+}
+
+func _ScoreService_SubmitScore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitScoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScoreServiceServer).SubmitScore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScoreService_SubmitScore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScoreServiceServer).SubmitScore(ctx, req.(*SubmitScoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScoreService_SubmitScores_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitScoresRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScoreServiceServer).SubmitScores(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScoreService_SubmitScores_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScoreServiceServer).SubmitScores(ctx, req.(*SubmitScoresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScoreService_StreamScores_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ScoreServiceServer).StreamScores(&grpc.GenericServerStream[SubmitScoreRequest, SubmitScoreResult]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ScoreService_StreamScoresServer = grpc.BidiStreamingServer[SubmitScoreRequest, SubmitScoreResult]
+
+// ScoreService_ServiceDesc is the grpc.ServiceDesc for ScoreService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ScoreService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "leaderboard.v1.ScoreService",
+	HandlerType: (*ScoreServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SubmitScore",
+			Handler:    _ScoreService_SubmitScore_Handler,
+		},
+		{
+			MethodName: "SubmitScores",
+			Handler:    _ScoreService_SubmitScores_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamScores",
+			Handler:       _ScoreService_StreamScores_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "leaderboard/v1/leaderboard.proto",
+}
+
+const (
+	LeaderboardService_GetGlobalLeaderboard_FullMethodName = "/leaderboard.v1.LeaderboardService/GetGlobalLeaderboard"
+	LeaderboardService_GetGameLeaderboard_FullMethodName   = "/leaderboard.v1.LeaderboardService/GetGameLeaderboard"
+	LeaderboardService_GetMyRank_FullMethodName            = "/leaderboard.v1.LeaderboardService/GetMyRank"
+	LeaderboardService_GetGameRank_FullMethodName          = "/leaderboard.v1.LeaderboardService/GetGameRank"
+	LeaderboardService_WatchRank_FullMethodName            = "/leaderboard.v1.LeaderboardService/WatchRank"
+)
+
+// LeaderboardServiceClient is the client API for LeaderboardService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// LeaderboardService reads the boards. Calls need an
+// "authorization: Bearer <access token>" metadata entry.
+type LeaderboardServiceClient interface {
+	// GetGlobalLeaderboard returns a page of the global board.
+	GetGlobalLeaderboard(ctx context.Context, in *GetGlobalLeaderboardRequest, opts ...grpc.CallOption) (*LeaderboardPage, error)
+	// GetGameLeaderboard returns a page of a game's board.
+	GetGameLeaderboard(ctx context.Context, in *GetGameLeaderboardRequest, opts ...grpc.CallOption) (*LeaderboardPage, error)
+	// GetMyRank returns the caller's position on the global board.
+	GetMyRank(ctx context.Context, in *GetMyRankRequest, opts ...grpc.CallOption) (*PlayerRank, error)
+	// GetGameRank returns the caller's position on a game's board.
+	GetGameRank(ctx context.Context, in *GetGameRankRequest, opts ...grpc.CallOption) (*PlayerRank, error)
+	// WatchRank sends the caller's rank at once and again whenever it changes,
+	// until the client cancels the call.
+	WatchRank(ctx context.Context, in *WatchRankRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PlayerRank], error)
+}
+
+type leaderboardServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLeaderboardServiceClient(cc grpc.ClientConnInterface) LeaderboardServiceClient {
+	return &leaderboardServiceClient{cc}
+}
+
+func (c *leaderboardServiceClient) GetGlobalLeaderboard(ctx context.Context, in *GetGlobalLeaderboardRequest, opts ...grpc.CallOption) (*LeaderboardPage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaderboardPage)
+	err := c.cc.Invoke(ctx, LeaderboardService_GetGlobalLeaderboard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardServiceClient) GetGameLeaderboard(ctx context.Context, in *GetGameLeaderboardRequest, opts ...grpc.CallOption) (*LeaderboardPage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaderboardPage)
+	err := c.cc.Invoke(ctx, LeaderboardService_GetGameLeaderboard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardServiceClient) GetMyRank(ctx context.Context, in *GetMyRankRequest, opts ...grpc.CallOption) (*PlayerRank, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlayerRank)
+	err := c.cc.Invoke(ctx, LeaderboardService_GetMyRank_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardServiceClient) GetGameRank(ctx context.Context, in *GetGameRankRequest, opts ...grpc.CallOption) (*PlayerRank, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlayerRank)
+	err := c.cc.Invoke(ctx, LeaderboardService_GetGameRank_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardServiceClient) WatchRank(ctx context.Context, in *WatchRankRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PlayerRank], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LeaderboardService_ServiceDesc.Streams[0], LeaderboardService_WatchRank_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRankRequest, PlayerRank]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LeaderboardService_WatchRankClient = grpc.ServerStreamingClient[PlayerRank]
+
+// LeaderboardServiceServer is the server API for LeaderboardService service.
+// All implementations must embed UnimplementedLeaderboardServiceServer
+// for forward compatibility.
+//
+// LeaderboardService reads the boards. Calls need an
+// "authorization: Bearer <access token>" metadata entry.
+type LeaderboardServiceServer interface {
+	// GetGlobalLeaderboard returns a page of the global board.
+	GetGlobalLeaderboard(context.Context, *GetGlobalLeaderboardRequest) (*LeaderboardPage, error)
+	// GetGameLeaderboard returns a page of a game's board.
+	GetGameLeaderboard(context.Context, *GetGameLeaderboardRequest) (*LeaderboardPage, error)
+	// GetMyRank returns the caller's position on the global board.
+	GetMyRank(context.Context, *GetMyRankRequest) (*PlayerRank, error)
+	// GetGameRank returns the caller's position on a game's board.
+	GetGameRank(context.Context, *GetGameRankRequest) (*PlayerRank, error)
+	// WatchRank sends the caller's rank at once and again whenever it changes,
+	// until the client cancels the call.
+	WatchRank(*WatchRankRequest, grpc.ServerStreamingServer[PlayerRank]) error
+	mustEmbedUnimplementedLeaderboardServiceServer()
+}
+
+// UnimplementedLeaderboardServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLeaderboardServiceServer struct{}
+
+func (UnimplementedLeaderboardServiceServer) GetGlobalLeaderboard(context.Context, *GetGlobalLeaderboardRequest) (*LeaderboardPage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGlobalLeaderboard not implemented")
+}
+func (UnimplementedLeaderboardServiceServer) GetGameLeaderboard(context.Context, *GetGameLeaderboardRequest) (*LeaderboardPage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGameLeaderboard not implemented")
+}
+func (UnimplementedLeaderboardServiceServer) GetMyRank(context.Context, *GetMyRankRequest) (*PlayerRank, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMyRank not implemented")
+}
+func (UnimplementedLeaderboardServiceServer) GetGameRank(context.Context, *GetGameRankRequest) (*PlayerRank, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGameRank not implemented")
+}
+func (UnimplementedLeaderboardServiceServer) WatchRank(*WatchRankRequest, grpc.ServerStreamingServer[PlayerRank]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchRank not implemented")
+}
+func (UnimplementedLeaderboardServiceServer) mustEmbedUnimplementedLeaderboardServiceServer() {}
+func (UnimplementedLeaderboardServiceServer) testEmbeddedByValue()                            {}
+
+// UnsafeLeaderboardServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LeaderboardServiceServer will
+// result in compilation errors.
+type UnsafeLeaderboardServiceServer interface {
+	mustEmbedUnimplementedLeaderboardServiceServer()
+}
+
+func RegisterLeaderboardServiceServer(s grpc.ServiceRegistrar, srv LeaderboardServiceServer) {
+	// If the following call pancis, it indicates UnimplementedLeaderboardServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&LeaderboardService_ServiceDesc, srv)
+}
+
+func _LeaderboardService_GetGlobalLeaderboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGlobalLeaderboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServiceServer).GetGlobalLeaderboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaderboardService_GetGlobalLeaderboard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServiceServer).GetGlobalLeaderboard(ctx, req.(*GetGlobalLeaderboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaderboardService_GetGameLeaderboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGameLeaderboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServiceServer).GetGameLeaderboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaderboardService_GetGameLeaderboard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServiceServer).GetGameLeaderboard(ctx, req.(*GetGameLeaderboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaderboardService_GetMyRank_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMyRankRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServiceServer).GetMyRank(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaderboardService_GetMyRank_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServiceServer).GetMyRank(ctx, req.(*GetMyRankRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaderboardService_GetGameRank_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGameRankRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServiceServer).GetGameRank(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaderboardService_GetGameRank_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServiceServer).GetGameRank(ctx, req.(*GetGameRankRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaderboardService_WatchRank_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRankRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LeaderboardServiceServer).WatchRank(m, &grpc.GenericServerStream[WatchRankRequest, PlayerRank]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LeaderboardService_WatchRankServer = grpc.ServerStreamingServer[PlayerRank]
+
+// LeaderboardService_ServiceDesc is the grpc.ServiceDesc for LeaderboardService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LeaderboardService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "leaderboard.v1.LeaderboardService",
+	HandlerType: (*LeaderboardServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetGlobalLeaderboard",
+			Handler:    _LeaderboardService_GetGlobalLeaderboard_Handler,
+		},
+		{
+			MethodName: "GetGameLeaderboard",
+			Handler:    _LeaderboardService_GetGameLeaderboard_Handler,
+		},
+		{
+			MethodName: "GetMyRank",
+			Handler:    _LeaderboardService_GetMyRank_Handler,
+		},
+		{
+			MethodName: "GetGameRank",
+			Handler:    _LeaderboardService_GetGameRank_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchRank",
+			Handler:       _LeaderboardService_WatchRank_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "leaderboard/v1/leaderboard.proto",
+}
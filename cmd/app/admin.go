@@ -0,0 +1,476 @@
+package main
+
+import (
+	"OnlineLeadership/config"
+	"OnlineLeadership/internal/domain"
+	"OnlineLeadership/internal/infrastructure/auth"
+	"OnlineLeadership/internal/infrastructure/logger"
+	"OnlineLeadership/internal/infrastructure/redis"
+	"OnlineLeadership/internal/infrastructure/repository"
+	"OnlineLeadership/internal/usecase"
+	"OnlineLeadership/internal/usecase/leaderboard"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/spf13/pflag"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const adminUsage = `usage: app admin [flags] <group> <command> [args]
+
+games:
+  games list [--all]                     list active public games, or every game
+  games create --name N --slug S [--description D] [--aggregation sum|max] [--visibility public|hidden]
+  games archive <game>                   retire a game, keeping its boards readable
+  games restore <game>                   reopen an archived game
+  games rotate-secret <game>             issue a new submission signing secret
+
+users:
+  users show <username>                  print the user's profile, bans and ranks
+  users grant <username> <role>          set the role to player, moderator or admin
+  users ban <username> [--scopes login,submit,visibility] [--reason R] [--duration 24h]
+
+boards:
+  boards rebuild <game>... | --all       recompute game boards from the score history
+  boards export <game>|global [--region R] [--format csv|json]
+
+<game> is a game id or slug.
+
+flags:
+  --config path    configuration file, as for the server (default config.yml)
+  --actor name     username recorded as the author in the audit log (required)
+  -v, --verbose    log at the configured level instead of warnings only`
+
+// errAdminUsage makes runAdmin print the usage and exit with code 2.
+var errAdminUsage = errors.New("invalid usage")
+
+type adminCLI struct {
+	services *usecase.Service
+	actorID  uuid.UUID
+	out      io.Writer
+}
+
+// runAdmin implements the admin subcommand and returns the exit code. It wires
+// the same usecases as the server, so every change goes through the same
+// validation and audit log. The caches of running servers are not told about
+// the change: they pick it up when their entries expire.
+func runAdmin(args []string) int {
+	flags := pflag.NewFlagSet("admin", pflag.ContinueOnError)
+	flags.SetInterspersed(false)
+	flags.Usage = func() { fmt.Fprintln(os.Stderr, adminUsage) }
+	path := flags.String("config", "config.yml", "")
+	actor := flags.String("actor", "", "")
+	verbose := flags.BoolP("verbose", "v", false, "")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if flags.NArg() < 2 {
+		fmt.Fprintln(os.Stderr, adminUsage)
+		return 2
+	}
+	if *actor == "" {
+		fmt.Fprintf(os.Stderr, "--actor is required\n\n%s\n", adminUsage)
+		return 2
+	}
+
+	var configArgs []string
+	if flags.Changed("config") {
+		configArgs = []string{"--config", *path}
+	}
+	cfg, err := config.Load(configArgs)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if cfg.Leaderboard.Store == config.LeaderboardMemory {
+		// The boards live in the server process, out of reach of this one.
+		fmt.Fprintln(os.Stderr, "warning: leaderboard.store is memory, board reads and writes only see this process")
+	}
+	// Logs go to stderr so that exports can be piped.
+	level := "warn"
+	if *verbose {
+		level = cfg.Log.Level
+	}
+	log := logger.NewWithWriter(os.Stderr, level, cfg.Log.Format)
+
+	ctx := context.Background()
+	db, err := connectDB(ctx, cfg, log)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "db connect failed:", err)
+		return 1
+	}
+	defer db.Close()
+	var rdb *goredis.Client
+	if cfg.UsesRedis() {
+		rdb = redis.InitRedis(cfg.Redis.Addr, cfg.Redis.Password, cfg.Redis.DB)
+		defer rdb.Close()
+		if err := rdb.Ping(ctx).Err(); err != nil {
+			fmt.Fprintln(os.Stderr, "redis connect failed:", err)
+			return 1
+		}
+	}
+	tokenManager := auth.NewTokenManager(cfg.JWT.AccessSecret, cfg.JWT.RefreshSecret, cfg.JWT.AccessTTL, cfg.JWT.RefreshTTL)
+	cli := &adminCLI{
+		services: usecase.NewService(repository.NewRepository(db, rdb, cfg.Leaderboard.Store, log), log, tokenManager),
+		out:      os.Stdout,
+	}
+	user, err := cli.services.Admin.FindUser(ctx, *actor)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "actor %q: %v\n", *actor, err)
+		return 1
+	}
+	cli.actorID = user.Id
+
+	group, command, rest := flags.Arg(0), flags.Arg(1), flags.Args()[2:]
+	err = cli.run(ctx, group, command, rest)
+	if errors.Is(err, errAdminUsage) {
+		fmt.Fprintf(os.Stderr, "%v\n\n%s\n", err, adminUsage)
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "admin", group, command, "failed:", err)
+		return 1
+	}
+	return 0
+}
+
+func (c *adminCLI) run(ctx context.Context, group, command string, args []string) error {
+	switch group + " " + command {
+	case "games list":
+		return c.listGames(ctx, args)
+	case "games create":
+		return c.createGame(ctx, args)
+	case "games archive":
+		return c.withGame(ctx, args, func(game domain.Game) error {
+			return c.services.Admin.ArchiveGame(ctx, c.actorID, game.Id)
+		})
+	case "games restore":
+		return c.withGame(ctx, args, func(game domain.Game) error {
+			return c.services.Admin.RestoreGame(ctx, c.actorID, game.Id)
+		})
+	case "games rotate-secret":
+		return c.withGame(ctx, args, func(game domain.Game) error {
+			secret, err := c.services.Admin.RotateSigningSecret(ctx, c.actorID, game.Id)
+			if err == nil {
+				fmt.Fprintln(c.out, secret)
+			}
+			return err
+		})
+	case "users show":
+		return c.showUser(ctx, args)
+	case "users grant":
+		return c.grantRole(ctx, args)
+	case "users ban":
+		return c.banUser(ctx, args)
+	case "boards rebuild":
+		return c.rebuildBoards(ctx, args)
+	case "boards export":
+		return c.exportBoard(ctx, args)
+	}
+	return fmt.Errorf("%w: unknown command %q", errAdminUsage, group+" "+command)
+}
+
+// parseArgs parses the command's flags and checks that exactly n positional
+// arguments remain.
+func parseArgs(flags *pflag.FlagSet, args []string, n int) ([]string, error) {
+	flags.SetOutput(io.Discard)
+	if err := flags.Parse(args); err != nil {
+		return nil, fmt.Errorf("%w: %v", errAdminUsage, err)
+	}
+	if flags.NArg() != n {
+		return nil, fmt.Errorf("%w: %s takes %d argument(s)", errAdminUsage, flags.Name(), n)
+	}
+	return flags.Args(), nil
+}
+
+// findGame resolves a game by id or slug, archived and hidden games included.
+func (c *adminCLI) findGame(ctx context.Context, ref string) (domain.Game, error) {
+	if id, err := uuid.Parse(ref); err == nil {
+		return c.services.Admin.GetGame(ctx, id)
+	}
+	games, err := c.services.Admin.GetGames(ctx)
+	if err != nil {
+		return domain.Game{}, err
+	}
+	for _, game := range games {
+		if game.Slug == ref {
+			return game, nil
+		}
+	}
+	return domain.Game{}, fmt.Errorf("%w: %s", domain.ErrGameNotFound, ref)
+}
+
+func (c *adminCLI) withGame(ctx context.Context, args []string, fn func(domain.Game) error) error {
+	args, err := parseArgs(pflag.NewFlagSet("game", pflag.ContinueOnError), args, 1)
+	if err != nil {
+		return err
+	}
+	game, err := c.findGame(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	return fn(game)
+}
+
+func (c *adminCLI) listGames(ctx context.Context, args []string) error {
+	flags := pflag.NewFlagSet("games list", pflag.ContinueOnError)
+	all := flags.Bool("all", false, "")
+	if _, err := parseArgs(flags, args, 0); err != nil {
+		return err
+	}
+	list := c.services.Admin.ListGames
+	if *all {
+		list = c.services.Admin.GetGames
+	}
+	games, err := list(ctx)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSLUG\tNAME\tAGGREGATION\tVISIBILITY\tSIGNED\tSTATUS")
+	for _, game := range games {
+		cfg := game.Config.WithDefaults()
+		status := "active"
+		if game.Archived() {
+			status = "archived"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%t\t%s\n", game.Id, game.Slug, game.Name, cfg.Aggregation, cfg.Visibility, game.Signed(), status)
+	}
+	return w.Flush()
+}
+
+func (c *adminCLI) createGame(ctx context.Context, args []string) error {
+	flags := pflag.NewFlagSet("games create", pflag.ContinueOnError)
+	var game domain.Game
+	flags.StringVar(&game.Name, "name", "", "")
+	flags.StringVar(&game.Slug, "slug", "", "")
+	flags.StringVar(&game.Description, "description", "", "")
+	flags.StringVar(&game.Config.Aggregation, "aggregation", "", "")
+	flags.StringVar(&game.Config.Visibility, "visibility", "", "")
+	if _, err := parseArgs(flags, args, 0); err != nil {
+		return err
+	}
+	id, err := c.services.Admin.Create(ctx, c.actorID, game)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(c.out, id)
+	return nil
+}
+
+func (c *adminCLI) showUser(ctx context.Context, args []string) error {
+	args, err := parseArgs(pflag.NewFlagSet("users show", pflag.ContinueOnError), args, 1)
+	if err != nil {
+		return err
+	}
+	user, err := c.services.Admin.FindUser(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "id:\t%s\nusername:\t%s\nemail:\t%s\nrole:\t%s\nregion:\t%s\n", user.Id, user.Username, user.Email, user.Role, user.Region)
+
+	bans, err := c.services.Bans.ListBans(ctx, user.Id)
+	if err != nil {
+		return err
+	}
+	for _, ban := range bans {
+		if ban.LiftedAt != nil || (ban.ExpiresAt != nil && ban.ExpiresAt.Before(time.Now())) {
+			continue
+		}
+		expires := "never"
+		if ban.ExpiresAt != nil {
+			expires = ban.ExpiresAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "ban:\t%s %s expires %s: %s\n", ban.Id, strings.Join(ban.Scopes, ","), expires, ban.Reason)
+	}
+
+	rank, err := c.services.Leaderboard.GetMyRank(ctx, user.Id)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "global:\t%s\n", formatRank(rank))
+	history, err := c.services.Profile.ListScores(ctx, domain.ScoreHistoryFilter{UserID: user.Id, Limit: 1})
+	if err != nil {
+		return err
+	}
+	for _, summary := range history.Summary {
+		rank, err := c.services.Leaderboard.GetGameRank(ctx, user.Id, summary.GameID)
+		if err != nil {
+			return err
+		}
+		name := summary.GameID.String()
+		if game, err := c.services.Admin.GetGame(ctx, summary.GameID); err == nil {
+			name = game.Slug
+		}
+		fmt.Fprintf(w, "game %s:\t%s, best %d over %d submissions\n", name, formatRank(rank), summary.Best, summary.Count)
+	}
+	return w.Flush()
+}
+
+func formatRank(rank domain.PlayerRank) string {
+	if !rank.Overall.Ranked() {
+		return "unranked"
+	}
+	s := fmt.Sprintf("#%d of %d", rank.Overall.Rank, rank.Overall.Total)
+	if rank.Region != "" && rank.Regional.Ranked() {
+		s += fmt.Sprintf(", #%d of %d in %s", rank.Regional.Rank, rank.Regional.Total, rank.Region)
+	}
+	return s
+}
+
+func (c *adminCLI) grantRole(ctx context.Context, args []string) error {
+	args, err := parseArgs(pflag.NewFlagSet("users grant", pflag.ContinueOnError), args, 2)
+	if err != nil {
+		return err
+	}
+	user, err := c.services.Admin.FindUser(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	return c.services.Admin.SetRole(ctx, c.actorID, user.Id, args[1])
+}
+
+func (c *adminCLI) banUser(ctx context.Context, args []string) error {
+	flags := pflag.NewFlagSet("users ban", pflag.ContinueOnError)
+	scopes := flags.StringSlice("scopes", []string{domain.BanScopeLogin, domain.BanScopeSubmit, domain.BanScopeVisibility}, "")
+	reason := flags.String("reason", "", "")
+	duration := flags.Duration("duration", 0, "")
+	args, err := parseArgs(flags, args, 1)
+	if err != nil {
+		return err
+	}
+	user, err := c.services.Admin.FindUser(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	id, err := c.services.Bans.Ban(ctx, c.actorID, user.Id, *scopes, *reason, *duration)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(c.out, id)
+	return nil
+}
+
+func (c *adminCLI) rebuildBoards(ctx context.Context, args []string) error {
+	flags := pflag.NewFlagSet("boards rebuild", pflag.ContinueOnError)
+	all := flags.Bool("all", false, "")
+	flags.SetOutput(io.Discard)
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", errAdminUsage, err)
+	}
+	if *all == (flags.NArg() > 0) {
+		return fmt.Errorf("%w: boards rebuild takes games or --all", errAdminUsage)
+	}
+
+	var games []domain.Game
+	if *all {
+		list, err := c.services.Admin.GetGames(ctx)
+		if err != nil {
+			return err
+		}
+		for _, game := range list {
+			if !game.Archived() {
+				games = append(games, game)
+			}
+		}
+	}
+	for _, ref := range flags.Args() {
+		game, err := c.findGame(ctx, ref)
+		if err != nil {
+			return err
+		}
+		games = append(games, game)
+	}
+	for _, game := range games {
+		players, err := c.services.Admin.RebuildLeaderboard(ctx, c.actorID, game.Id)
+		if err != nil {
+			return fmt.Errorf("%s: %w", game.Slug, err)
+		}
+		fmt.Fprintf(c.out, "%s: %d players\n", game.Slug, players)
+	}
+	return nil
+}
+
+// exportBoard writes a whole board, page by page, as CSV or a JSON array.
+func (c *adminCLI) exportBoard(ctx context.Context, args []string) error {
+	flags := pflag.NewFlagSet("boards export", pflag.ContinueOnError)
+	region := flags.String("region", "", "")
+	format := flags.String("format", "csv", "")
+	args, err := parseArgs(flags, args, 1)
+	if err != nil {
+		return err
+	}
+	if *format != "csv" && *format != "json" {
+		return fmt.Errorf("%w: --format must be csv or json", errAdminUsage)
+	}
+
+	fetch := func(page domain.PageRequest) (domain.LeaderboardPage, error) {
+		return c.services.Leaderboard.GetGlobalLeaderboard(ctx, *region, page)
+	}
+	if args[0] != "global" {
+		game, err := c.findGame(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		fetch = func(page domain.PageRequest) (domain.LeaderboardPage, error) {
+			return c.services.Leaderboard.GetLeaderboard(ctx, game.Id, *region, page)
+		}
+	}
+
+	rows := csv.NewWriter(c.out)
+	if *format == "csv" {
+		rows.Write([]string{"rank", "user_id", "score"})
+	} else {
+		fmt.Fprint(c.out, "[")
+	}
+	page := domain.PageRequest{Limit: leaderboard.MaxPageSize}
+	written := 0
+	for {
+		res, err := fetch(page)
+		if err != nil {
+			return err
+		}
+		for _, user := range res.Users {
+			if *format == "csv" {
+				rows.Write([]string{strconv.FormatInt(user.Rank, 10), user.UserID.String(), strconv.FormatInt(user.Score, 10)})
+				continue
+			}
+			line, err := json.Marshal(user)
+			if err != nil {
+				return err
+			}
+			if written > 0 {
+				fmt.Fprint(c.out, ",")
+			}
+			fmt.Fprintf(c.out, "\n  %s", line)
+			written++
+		}
+		if res.NextCursor == "" {
+			break
+		}
+		cursor, err := domain.DecodeCursor(res.NextCursor)
+		if err != nil {
+			return err
+		}
+		page.Cursor = &cursor
+	}
+	if *format == "csv" {
+		rows.Flush()
+		return rows.Error()
+	}
+	_, err = fmt.Fprintln(c.out, "\n]")
+	return err
+}
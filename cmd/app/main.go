@@ -18,17 +18,27 @@
 package main
 
 import (
+	"OnlineLeadership/config"
 	_ "OnlineLeadership/docs"
 	"OnlineLeadership/internal/infrastructure/auth"
 	"OnlineLeadership/internal/infrastructure/logger"
+	"OnlineLeadership/internal/infrastructure/metrics"
 	"OnlineLeadership/internal/infrastructure/postgres"
+	"OnlineLeadership/internal/infrastructure/postgres/migration"
+	"OnlineLeadership/internal/infrastructure/ratelimit"
 	"OnlineLeadership/internal/infrastructure/redis"
 	"OnlineLeadership/internal/infrastructure/repository"
+	"OnlineLeadership/internal/infrastructure/tracing"
+	grpcserver "OnlineLeadership/internal/interfaces/grpc/server"
 	"OnlineLeadership/internal/interfaces/http/handler"
 	"OnlineLeadership/internal/interfaces/http/middleware"
 	"OnlineLeadership/internal/usecase"
 	"context"
-	"github.com/spf13/viper"
+	"errors"
+	"fmt"
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/jmoiron/sqlx"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -36,77 +46,165 @@ import (
 )
 
 func main() {
-	log := logger.New("dev")
-	ctx := context.Background()
-	log.Info(ctx, "App is running")
-	if err := initConfig(); err != nil {
-		log.Error(ctx, "init config error : ", err.Error())
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "migrate":
+			os.Exit(runMigrate(os.Args[2:]))
+		case "admin":
+			os.Exit(runAdmin(os.Args[2:]))
+		}
 	}
-	accessSecret := os.Getenv("JWT_ACCESS_SECRET")
-	refreshSecret := os.Getenv("JWT_REFRESH_SECRET")
 
-	if accessSecret == "" || refreshSecret == "" {
-		log.Error(ctx, "JWT secrets are not set")
+	ctx := context.Background()
+	cfg, err := config.Load(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
+	log := logger.New(cfg.Log.Level, cfg.Log.Format)
+	log.Info(ctx, "App is running")
 
-	retryCfg := postgres.RetryConfig{
-		MaxAttempts: 10,
-		Delay:       3 * time.Second,
-		Timeout:     30 * time.Second,
+	shutdownTracing, err := tracing.Init(ctx, tracing.Config{
+		ServiceName: "leaderboard",
+		Exporter:    cfg.Tracing.Exporter,
+		Endpoint:    cfg.Tracing.Endpoint,
+		Insecure:    cfg.Tracing.Insecure,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		log.Error(ctx, "tracing init failed", "error", err)
+		return
 	}
-	log.Info(ctx, "db config",
-		"sslmode", viper.GetString("db.sslmode"),
-	)
 
-	db, err := postgres.ConnectWithRetry(
-		ctx,
-		retryCfg,
-		viper.GetString("db.username"),
-		os.Getenv("DB_PASSWORD"),
-		viper.GetString("db.host"),
-		viper.GetString("db.port"),
-		viper.GetString("db.dbname"),
-		viper.GetString("db.sslmode"),
-	)
+	db, err := connectDB(ctx, cfg, log)
 	if err != nil {
 		log.Error(ctx, "db connect failed", "error", err)
 		return
 	}
-	tokenManager := auth.NewTokenManager(accessSecret, refreshSecret)
-	dbredis := redis.InitRedis()
-	if err := dbredis.Ping(context.Background()).Err(); err != nil {
-		log.Error(ctx, "redis connection error: %v", err)
+	if cfg.DB.MigrateOnStart {
+		if err := migration.NewMigrator(db, log).Up(ctx); err != nil {
+			log.Error(ctx, "migrations failed", "error", err)
+			return
+		}
+	}
+	tokenManager := auth.NewTokenManager(cfg.JWT.AccessSecret, cfg.JWT.RefreshSecret, cfg.JWT.AccessTTL, cfg.JWT.RefreshTTL)
+	// Without a store that needs Redis the service runs on Postgres alone.
+	var dbredis *goredis.Client
+	if cfg.UsesRedis() {
+		dbredis = redis.InitRedis(cfg.Redis.Addr, cfg.Redis.Password, cfg.Redis.DB)
+		if err := dbredis.Ping(context.Background()).Err(); err != nil {
+			// The instance keeps running but reports not ready until Redis is back.
+			log.Error(ctx, "redis connection error", "error", err)
+		}
 	}
 
-	repos := repository.NewRepository(db, dbredis, log)
+	repos := repository.NewRepository(db, dbredis, cfg.Leaderboard.Store, log)
 	services := usecase.NewService(repos, log, tokenManager)
-	handlers := handler.NewHandler(services, log)
+
+	metrics.RegisterDBStats(db.DB, cfg.DB.DBName)
+	metrics.RegisterBoardSizes(services.Leaderboard.BoardSizes, 5*time.Second)
+
+	// Lift expired bans and restore the visibility of their users.
+	sweepCtx, stopSweeper := context.WithCancel(ctx)
+	go services.Bans.RunExpirySweeper(sweepCtx, time.Minute)
+	// Snapshot every player's rank for the progress charts.
+	go services.Progress.RunSnapshotter(sweepCtx, time.Hour)
+
+	limiter := newLimiter(cfg.RateLimit, dbredis)
+	handlers := handler.NewHandler(services, log, limiter)
 	router := handlers.InitRouter()
 	routerWithMiddleware := middleware.RequestID(router)
 	srv := new(handler.Server)
 	go func() {
-		log.Info(ctx, "Leaderboard app starting", "port", viper.GetString("port"))
-		if err := srv.Run(viper.GetString("port"), routerWithMiddleware); err != nil {
+		log.Info(ctx, "Leaderboard app starting", "port", cfg.Server.Port)
+		if err := srv.Run(cfg.Server.Port, routerWithMiddleware); err != nil {
 			log.Error(ctx, "server run error", "error", err)
 		}
 	}()
 
+	grpcSrv := grpcserver.NewServer(services, log, limiter)
+	go func() {
+		log.Info(ctx, "gRPC server starting", "port", cfg.Server.GRPCPort)
+		if err := grpcSrv.Run(cfg.Server.GRPCPort); err != nil {
+			log.Error(ctx, "grpc server run error", "error", err)
+		}
+	}()
+
+	// Metrics are served on a separate port that is not exposed publicly.
+	metricsSrv := new(handler.Server)
+	go func() {
+		log.Info(ctx, "Metrics server starting", "port", cfg.Server.MetricsPort)
+		if err := metricsSrv.Run(cfg.Server.MetricsPort, metrics.Handler()); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error(ctx, "metrics server run error", "error", err)
+		}
+	}()
+
 	log.Info(ctx, "Leaderboard app starting")
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGTERM, syscall.SIGINT)
 	<-quit
 	log.Info(ctx, "Leaderboard is shutting down")
+	// Fail readiness first and give the orchestrator time to stop routing
+	// traffic here before in-flight requests are drained.
+	services.Health.Drain()
+	time.Sleep(cfg.Server.ShutdownDrainDelay)
 	if err := srv.Shutdown(); err != nil {
 		log.Error(ctx, "Error occured on server shutting down: ", err.Error())
 	}
+	// Rank watches only end when their client leaves, so they are cut after a grace period.
+	grpcCtx, cancelGRPC := context.WithTimeout(ctx, 10*time.Second)
+	grpcSrv.Shutdown(grpcCtx)
+	cancelGRPC()
+	if err := metricsSrv.Shutdown(); err != nil {
+		log.Error(ctx, "metrics server shutdown error", err.Error())
+	}
+	stopSweeper()
 	if err := db.Close(); err != nil {
 		log.Error(ctx, "Error occured on db connection close: ", err.Error())
 	}
+	// Flush the spans still buffered by the exporter.
+	flushCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := shutdownTracing(flushCtx); err != nil {
+		log.Error(ctx, "tracing shutdown error", "error", err)
+	}
 }
 
-func initConfig() error {
-	viper.SetConfigName("config") // config.yml
-	viper.SetConfigType("yaml")   // 🔥 важно
-	viper.AddConfigPath(".")
-	return viper.ReadInConfig()
+func connectDB(ctx context.Context, cfg *config.Config, log *logger.SlogLogger) (*sqlx.DB, error) {
+	retryCfg := postgres.RetryConfig{
+		MaxAttempts: cfg.DB.ConnectRetries,
+		Delay:       cfg.DB.ConnectDelay,
+		Timeout:     cfg.DB.ConnectTimeout,
+	}
+	log.Info(ctx, "db config",
+		"host", cfg.DB.Host,
+		"sslmode", cfg.DB.SSLMode,
+	)
+	return postgres.ConnectWithRetry(
+		ctx,
+		retryCfg,
+		cfg.DB.Username,
+		cfg.DB.Password,
+		cfg.DB.Host,
+		cfg.DB.Port,
+		cfg.DB.DBName,
+		cfg.DB.SSLMode,
+	)
+}
+
+// newLimiter returns the rate limiter configured by cfg, or nil when rate
+// limiting is disabled.
+func newLimiter(cfg config.RateLimitConfig, rdb *goredis.Client) *ratelimit.Limiter {
+	if !cfg.Enabled {
+		return nil
+	}
+	var store ratelimit.Store = ratelimit.NewRedisStore(rdb)
+	if cfg.Store == config.RateLimitMemory {
+		store = ratelimit.NewMemoryStore()
+	}
+	routes := make(map[string]ratelimit.Policy, len(cfg.Routes))
+	for route, policy := range cfg.Routes {
+		routes[route] = ratelimit.Policy{Requests: policy.Requests, Window: policy.Window}
+	}
+	return ratelimit.New(store, ratelimit.Policy{Requests: cfg.Default.Requests, Window: cfg.Default.Window}, routes)
 }
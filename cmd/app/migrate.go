@@ -0,0 +1,124 @@
+package main
+
+import (
+	"OnlineLeadership/config"
+	"OnlineLeadership/internal/infrastructure/logger"
+	"OnlineLeadership/internal/infrastructure/postgres/migration"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const migrateUsage = `usage: app migrate <command> [flags]
+
+commands:
+  up [N]      apply all pending migrations, or the next N
+  down [N]    revert the last N migrations (default 1)
+  status      list the embedded migrations and which are applied
+  version     print the current schema version
+
+flags are those of the server, e.g. --config path/to/config.yml`
+
+// runMigrate implements the migrate subcommand and returns the exit code.
+func runMigrate(args []string) int {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+	command, args := args[0], args[1:]
+	switch command {
+	case "up", "down", "status", "version":
+	default:
+		fmt.Fprintf(os.Stderr, "unknown migrate command %q\n\n%s\n", command, migrateUsage)
+		return 2
+	}
+	steps := 0
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n <= 0 {
+			fmt.Fprintf(os.Stderr, "invalid number of migrations %q\n", args[0])
+			return 2
+		}
+		steps, args = n, args[1:]
+	}
+
+	cfg, err := config.Load(args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	ctx := context.Background()
+	log := logger.New(cfg.Log.Level, cfg.Log.Format)
+	db, err := connectDB(ctx, cfg, log)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "db connect failed:", err)
+		return 1
+	}
+	defer db.Close()
+	migrator := migration.NewMigrator(db, log)
+
+	switch command {
+	case "up":
+		if steps > 0 {
+			err = migrator.UpSteps(ctx, steps)
+		} else {
+			err = migrator.Up(ctx)
+		}
+	case "down":
+		err = migrator.Down(ctx, max(steps, 1))
+	case "status":
+		err = printStatus(ctx, migrator)
+	case "version":
+		var version uint
+		var dirty bool
+		if version, dirty, err = migrator.Version(ctx); err == nil {
+			fmt.Println(formatVersion(version, dirty))
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "migrate", command, "failed:", err)
+		return 1
+	}
+	if command == "up" || command == "down" {
+		return printVersion(ctx, migrator)
+	}
+	return 0
+}
+
+func printStatus(ctx context.Context, migrator *migration.Migrator) error {
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Println("schema version:", formatVersion(status.Version, status.Dirty))
+	for _, m := range status.Migrations {
+		state := "pending"
+		switch {
+		case m.Version == status.Version && status.Dirty:
+			state = "dirty"
+		case m.Version <= status.Version:
+			state = "applied"
+		}
+		fmt.Printf("%03d  %-8s %s\n", m.Version, state, m.Name)
+	}
+	return nil
+}
+
+func printVersion(ctx context.Context, migrator *migration.Migrator) int {
+	version, dirty, err := migrator.Version(ctx)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "read schema version failed:", err)
+		return 1
+	}
+	fmt.Println("schema version:", formatVersion(version, dirty))
+	return 0
+}
+
+func formatVersion(version uint, dirty bool) string {
+	if dirty {
+		return fmt.Sprintf("%d (dirty)", version)
+	}
+	return strconv.FormatUint(uint64(version), 10)
+}
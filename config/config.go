@@ -1,10 +1,336 @@
+// Package config loads the service configuration from config.yml, the
+// environment and command-line flags, in increasing order of precedence.
+//
+// Every key can be overridden by an environment variable named after its path
+// in upper case with dots replaced by underscores, e.g. DB_PASSWORD for
+// db.password. Secrets can also be read from files, as mounted by Docker and
+// Kubernetes secrets: JWT_ACCESS_SECRET_FILE=/run/secrets/jwt_access sets
+// jwt.access_secret to the trimmed content of that file.
 package config
 
+import (
+	"OnlineLeadership/internal/infrastructure/logger"
+	"errors"
+	"fmt"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Leaderboard stores, selected by leaderboard.store.
+const (
+	// LeaderboardRedis keeps the boards in Redis sorted sets, shared by the replicas.
+	LeaderboardRedis = "redis"
+	// LeaderboardMemory keeps the boards in process; they are lost on restart.
+	LeaderboardMemory = "memory"
+	// LeaderboardPostgres keeps the boards in the leaderboard_scores table.
+	LeaderboardPostgres = "postgres"
+)
+
+// Rate limit stores, selected by rate_limit.store.
+const (
+	// RateLimitRedis shares the windows between replicas.
+	RateLimitRedis = "redis"
+	// RateLimitMemory keeps the windows in the process, for a single instance or development.
+	RateLimitMemory = "memory"
+)
+
+// Trace exporters, selected by tracing.exporter.
+const (
+	TracingNone   = "none"
+	TracingStdout = "stdout"
+	TracingOTLP   = "otlp"
+)
+
 type Config struct {
-	Username     string
-	Password     string
-	Host         string
-	Port         string
-	DatabaseName string
-	SslMode      string
+	Server      ServerConfig      `mapstructure:"server"`
+	DB          DBConfig          `mapstructure:"db"`
+	Redis       RedisConfig       `mapstructure:"redis"`
+	JWT         JWTConfig         `mapstructure:"jwt"`
+	Log         LogConfig         `mapstructure:"log"`
+	Leaderboard LeaderboardConfig `mapstructure:"leaderboard"`
+	RateLimit   RateLimitConfig   `mapstructure:"rate_limit"`
+	Tracing     TracingConfig     `mapstructure:"tracing"`
+}
+
+type ServerConfig struct {
+	Port        string `mapstructure:"port"`
+	GRPCPort    string `mapstructure:"grpc_port"`
+	MetricsPort string `mapstructure:"metrics_port"`
+	// ShutdownDrainDelay is how long /readyz fails before the server stops.
+	ShutdownDrainDelay time.Duration `mapstructure:"shutdown_drain_delay"`
+}
+
+type DBConfig struct {
+	Username       string        `mapstructure:"username"`
+	Password       string        `mapstructure:"password"`
+	Host           string        `mapstructure:"host"`
+	Port           string        `mapstructure:"port"`
+	DBName         string        `mapstructure:"dbname"`
+	SSLMode        string        `mapstructure:"sslmode"`
+	ConnectRetries int           `mapstructure:"connect_retries"`
+	ConnectDelay   time.Duration `mapstructure:"connect_delay"`
+	ConnectTimeout time.Duration `mapstructure:"connect_timeout"`
+	// MigrateOnStart applies pending migrations before serving; replicas take
+	// turns through a Postgres advisory lock.
+	MigrateOnStart bool `mapstructure:"migrate_on_start"`
+}
+
+type RedisConfig struct {
+	Addr     string `mapstructure:"addr"`
+	Password string `mapstructure:"password"`
+	DB       int    `mapstructure:"db"`
+}
+
+type JWTConfig struct {
+	AccessSecret  string        `mapstructure:"access_secret"`
+	RefreshSecret string        `mapstructure:"refresh_secret"`
+	AccessTTL     time.Duration `mapstructure:"access_ttl"`
+	RefreshTTL    time.Duration `mapstructure:"refresh_ttl"`
+}
+
+type LogConfig struct {
+	// Level is one of debug, info, warn or error.
+	Level string `mapstructure:"level"`
+	// Format is text or json.
+	Format string `mapstructure:"format"`
+}
+
+type LeaderboardConfig struct {
+	// Store is redis, shared by the replicas, postgres, also shared but without
+	// Redis, or memory for a single instance whose boards are lost on restart.
+	Store string `mapstructure:"store"`
+}
+
+// RateLimitPolicy allows Requests per Window.
+type RateLimitPolicy struct {
+	Requests int           `mapstructure:"requests"`
+	Window   time.Duration `mapstructure:"window"`
+}
+
+type RateLimitConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Store is redis, shared by the replicas, or memory for a single instance.
+	// Unset, it is redis with the redis leaderboard store and memory otherwise,
+	// so that choosing another leaderboard store does not still require Redis.
+	Store   string          `mapstructure:"store"`
+	Default RateLimitPolicy `mapstructure:"default"`
+	// Routes overrides the default policy per route path, e.g. /auth/login.
+	Routes map[string]RateLimitPolicy `mapstructure:"routes"`
+}
+
+type TracingConfig struct {
+	Exporter    string  `mapstructure:"exporter"`
+	Endpoint    string  `mapstructure:"endpoint"`
+	Insecure    bool    `mapstructure:"insecure"`
+	SampleRatio float64 `mapstructure:"sample_ratio"`
+}
+
+// secrets are the keys that may also be read from the file named by <key>_file.
+var secrets = []string{"db.password", "redis.password", "jwt.access_secret", "jwt.refresh_secret"}
+
+func setDefaults(v *viper.Viper) {
+	v.SetDefault("server.port", "8080")
+	v.SetDefault("server.grpc_port", "50051")
+	v.SetDefault("server.metrics_port", "9090")
+	v.SetDefault("server.shutdown_drain_delay", 5*time.Second)
+
+	v.SetDefault("db.username", "postgres")
+	v.SetDefault("db.password", "")
+	v.SetDefault("db.host", "localhost")
+	v.SetDefault("db.port", "5432")
+	v.SetDefault("db.dbname", "leaderboard")
+	v.SetDefault("db.sslmode", "disable")
+	v.SetDefault("db.connect_retries", 10)
+	v.SetDefault("db.connect_delay", 3*time.Second)
+	v.SetDefault("db.connect_timeout", 30*time.Second)
+	v.SetDefault("db.migrate_on_start", false)
+
+	v.SetDefault("redis.addr", "127.0.0.1:6379")
+	v.SetDefault("redis.password", "")
+	v.SetDefault("redis.db", 0)
+
+	v.SetDefault("jwt.access_secret", "")
+	v.SetDefault("jwt.refresh_secret", "")
+	v.SetDefault("jwt.access_ttl", 30*time.Minute)
+	v.SetDefault("jwt.refresh_ttl", 7*24*time.Hour)
+
+	v.SetDefault("log.level", "info")
+	v.SetDefault("log.format", logger.FormatText)
+
+	v.SetDefault("leaderboard.store", LeaderboardRedis)
+
+	v.SetDefault("rate_limit.enabled", false)
+	v.SetDefault("rate_limit.store", "")
+	v.SetDefault("rate_limit.default.requests", 100)
+	v.SetDefault("rate_limit.default.window", time.Minute)
+
+	v.SetDefault("tracing.exporter", TracingNone)
+	v.SetDefault("tracing.endpoint", "")
+	v.SetDefault("tracing.insecure", false)
+	v.SetDefault("tracing.sample_ratio", 1.0)
+
+	// Registering the _file keys lets AutomaticEnv pick them up.
+	for _, key := range secrets {
+		v.SetDefault(key+"_file", "")
+	}
+}
+
+// Load reads the configuration file named by --config, applies environment and
+// flag overrides from args, resolves secret files and validates the result.
+// The returned error lists every problem found.
+func Load(args []string) (*Config, error) {
+	flags := pflag.NewFlagSet("leaderboard", pflag.ContinueOnError)
+	path := flags.String("config", "config.yml", "path to the configuration file")
+	flags.String("port", "", "HTTP port (server.port)")
+	flags.String("grpc-port", "", "gRPC port (server.grpc_port)")
+	flags.String("metrics-port", "", "Prometheus port (server.metrics_port)")
+	flags.String("log-level", "", "log level: debug, info, warn or error (log.level)")
+	flags.String("log-format", "", "log format: text or json (log.format)")
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	v := viper.New()
+	setDefaults(v)
+	v.SetConfigFile(*path)
+	v.SetConfigType("yaml")
+	// Without a file the defaults, environment and flags still apply, unless
+	// the file was asked for explicitly.
+	if err := v.ReadInConfig(); err != nil && (flags.Changed("config") || !errors.Is(err, fs.ErrNotExist)) {
+		return nil, fmt.Errorf("failed to read %s: %w", *path, err)
+	}
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+	for key, flag := range map[string]string{
+		"server.port":         "port",
+		"server.grpc_port":    "grpc-port",
+		"server.metrics_port": "metrics-port",
+		"log.level":           "log-level",
+		"log.format":          "log-format",
+	} {
+		// Unset flags would otherwise override the file with their empty default.
+		if f := flags.Lookup(flag); f.Changed {
+			v.Set(key, f.Value.String())
+		}
+	}
+
+	var errs []error
+	for _, key := range secrets {
+		file := v.GetString(key + "_file")
+		if file == "" {
+			continue
+		}
+		if v.GetString(key) != "" {
+			errs = append(errs, fmt.Errorf("%s: set either the value or %s_file, not both", key, key))
+			continue
+		}
+		secret, err := os.ReadFile(file)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s_file: %w", key, err))
+			continue
+		}
+		v.Set(key, strings.TrimSpace(string(secret)))
+	}
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+	if cfg.RateLimit.Store == "" {
+		cfg.RateLimit.Store = RateLimitMemory
+		if cfg.Leaderboard.Store == LeaderboardRedis {
+			cfg.RateLimit.Store = RateLimitRedis
+		}
+	}
+	errs = append(errs, cfg.validate()...)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid configuration:\n%w", errors.Join(errs...))
+	}
+	return &cfg, nil
+}
+
+// UsesRedis reports whether any configured store needs Redis: the redis
+// leaderboard store, which also remembers the submission nonces, or an enabled
+// rate limit with the redis store.
+func (c *Config) UsesRedis() bool {
+	return c.Leaderboard.Store == LeaderboardRedis || (c.RateLimit.Enabled && c.RateLimit.Store == RateLimitRedis)
+}
+
+func (c *Config) validate() []error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(validPort(c.Server.Port), "server.port: %q is not a valid port", c.Server.Port)
+	check(validPort(c.Server.GRPCPort), "server.grpc_port: %q is not a valid port", c.Server.GRPCPort)
+	check(validPort(c.Server.MetricsPort), "server.metrics_port: %q is not a valid port", c.Server.MetricsPort)
+	check(c.Server.GRPCPort != c.Server.Port, "server.grpc_port: must differ from server.port")
+	check(c.Server.MetricsPort != c.Server.Port && c.Server.MetricsPort != c.Server.GRPCPort, "server.metrics_port: must differ from server.port and server.grpc_port")
+	check(c.Server.ShutdownDrainDelay >= 0, "server.shutdown_drain_delay: must not be negative")
+
+	check(c.DB.Username != "", "db.username: is required")
+	check(c.DB.Host != "", "db.host: is required")
+	check(validPort(c.DB.Port), "db.port: %q is not a valid port", c.DB.Port)
+	check(c.DB.DBName != "", "db.dbname: is required")
+	switch c.DB.SSLMode {
+	case "disable", "allow", "prefer", "require", "verify-ca", "verify-full":
+	default:
+		errs = append(errs, fmt.Errorf("db.sslmode: %q is not a libpq sslmode", c.DB.SSLMode))
+	}
+	check(c.DB.ConnectRetries > 0, "db.connect_retries: must be positive")
+	check(c.DB.ConnectDelay >= 0, "db.connect_delay: must not be negative")
+	check(c.DB.ConnectTimeout > 0, "db.connect_timeout: must be positive")
+
+	check(!c.UsesRedis() || c.Redis.Addr != "", "redis.addr: is required")
+	check(c.Redis.DB >= 0, "redis.db: must not be negative")
+
+	check(c.JWT.AccessSecret != "", "jwt.access_secret: is required (set JWT_ACCESS_SECRET or JWT_ACCESS_SECRET_FILE)")
+	check(c.JWT.RefreshSecret != "", "jwt.refresh_secret: is required (set JWT_REFRESH_SECRET or JWT_REFRESH_SECRET_FILE)")
+	check(c.JWT.AccessSecret == "" || c.JWT.AccessSecret != c.JWT.RefreshSecret, "jwt.refresh_secret: must differ from jwt.access_secret")
+	check(c.JWT.AccessTTL > 0, "jwt.access_ttl: must be positive")
+	check(c.JWT.RefreshTTL > c.JWT.AccessTTL, "jwt.refresh_ttl: must be longer than jwt.access_ttl")
+
+	switch c.Log.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		errs = append(errs, fmt.Errorf("log.level: %q is not one of debug, info, warn, error", c.Log.Level))
+	}
+	check(c.Log.Format == logger.FormatText || c.Log.Format == logger.FormatJSON, "log.format: %q is not one of text, json", c.Log.Format)
+
+	switch c.Leaderboard.Store {
+	case LeaderboardRedis, LeaderboardMemory, LeaderboardPostgres:
+	default:
+		errs = append(errs, fmt.Errorf("leaderboard.store: %q is not one of redis, memory, postgres", c.Leaderboard.Store))
+	}
+
+	check(c.RateLimit.Store == RateLimitRedis || c.RateLimit.Store == RateLimitMemory, "rate_limit.store: %q is not one of redis, memory", c.RateLimit.Store)
+	check(validPolicy(c.RateLimit.Default), "rate_limit.default: requests and window must be positive")
+	for route, policy := range c.RateLimit.Routes {
+		check(validPolicy(policy), "rate_limit.routes.%s: requests and window must be positive", route)
+	}
+
+	switch c.Tracing.Exporter {
+	case TracingNone, TracingStdout, TracingOTLP:
+	default:
+		errs = append(errs, fmt.Errorf("tracing.exporter: %q is not one of none, stdout, otlp", c.Tracing.Exporter))
+	}
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio: must be between 0 and 1")
+	return errs
+}
+
+func validPort(port string) bool {
+	n, err := strconv.Atoi(port)
+	return err == nil && n > 0 && n < 65536
+}
+
+func validPolicy(p RateLimitPolicy) bool {
+	return p.Requests > 0 && p.Window > 0
 }
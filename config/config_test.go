@@ -0,0 +1,93 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// loadValid loads the defaults with the secrets set, which is a valid configuration.
+func loadValid(t *testing.T) *Config {
+	t.Helper()
+	t.Setenv("JWT_ACCESS_SECRET", "access")
+	t.Setenv("JWT_REFRESH_SECRET", "refresh")
+	cfg, err := Load(nil)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	return cfg
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(*Config)
+		// want is a substring of the expected error, empty when the configuration is valid.
+		want string
+	}{
+		{"defaults", func(*Config) {}, ""},
+		{"bad port", func(c *Config) { c.Server.Port = "http" }, "server.port"},
+		{"shared port", func(c *Config) { c.Server.GRPCPort = c.Server.Port }, "server.grpc_port"},
+		{"bad sslmode", func(c *Config) { c.DB.SSLMode = "on" }, "db.sslmode"},
+		{"missing redis", func(c *Config) { c.Redis.Addr = "" }, "redis.addr"},
+		{"redis unused", func(c *Config) {
+			c.Redis.Addr = ""
+			c.Leaderboard.Store = LeaderboardPostgres
+			c.RateLimit.Store = RateLimitMemory
+		}, ""},
+		{"redis rate limit", func(c *Config) {
+			c.Redis.Addr = ""
+			c.Leaderboard.Store = LeaderboardPostgres
+			c.RateLimit.Enabled = true
+			c.RateLimit.Store = RateLimitRedis
+		}, "redis.addr"},
+		{"same secrets", func(c *Config) { c.JWT.RefreshSecret = c.JWT.AccessSecret }, "jwt.refresh_secret"},
+		{"short refresh", func(c *Config) { c.JWT.RefreshTTL = c.JWT.AccessTTL }, "jwt.refresh_ttl"},
+		{"bad log level", func(c *Config) { c.Log.Level = "trace" }, "log.level"},
+		{"bad log format", func(c *Config) { c.Log.Format = "xml" }, "log.format"},
+		{"bad store", func(c *Config) { c.Leaderboard.Store = "mysql" }, "leaderboard.store"},
+		{"bad rate limit store", func(c *Config) { c.RateLimit.Store = "postgres" }, "rate_limit.store"},
+		{"bad route policy", func(c *Config) {
+			c.RateLimit.Routes = map[string]RateLimitPolicy{"/auth/login": {Requests: 0, Window: time.Minute}}
+		}, "rate_limit.routes./auth/login"},
+		{"bad exporter", func(c *Config) { c.Tracing.Exporter = "jaeger" }, "tracing.exporter"},
+		{"bad ratio", func(c *Config) { c.Tracing.SampleRatio = 2 }, "tracing.sample_ratio"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := loadValid(t)
+			tt.edit(cfg)
+			errs := cfg.validate()
+			if tt.want == "" {
+				if len(errs) > 0 {
+					t.Fatalf("validate() = %v, want no errors", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || !strings.HasPrefix(errs[0].Error(), tt.want) {
+				t.Fatalf("validate() = %v, want one error on %s", errs, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimitStoreDefault(t *testing.T) {
+	tests := []struct {
+		leaderboard string
+		want        string
+		redis       bool
+	}{
+		{LeaderboardRedis, RateLimitRedis, true},
+		{LeaderboardPostgres, RateLimitMemory, false},
+		{LeaderboardMemory, RateLimitMemory, false},
+	}
+	for _, tt := range tests {
+		t.Setenv("LEADERBOARD_STORE", tt.leaderboard)
+		t.Setenv("RATE_LIMIT_ENABLED", "true")
+		cfg := loadValid(t)
+		if cfg.RateLimit.Store != tt.want || cfg.UsesRedis() != tt.redis {
+			t.Errorf("leaderboard.store %s: rate_limit.store = %s, UsesRedis() = %v; want %s, %v",
+				tt.leaderboard, cfg.RateLimit.Store, cfg.UsesRedis(), tt.want, tt.redis)
+		}
+	}
+}
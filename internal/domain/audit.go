@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Audited actions.
+const (
+	AuditLogin       = "auth.login"
+	AuditLoginFailed = "auth.login_failed"
+	AuditRegister    = "auth.register"
+
+	AuditGameCreate        = "game.create"
+	AuditGameUpdate        = "game.update"
+	AuditGameArchive       = "game.archive"
+	AuditGameRestore       = "game.restore"
+	AuditGameDelete        = "game.delete"
+	AuditGameRotateSecret  = "game.rotate_secret"
+	AuditGameDisableSecret = "game.disable_secret"
+	AuditGameRebuildBoard  = "game.rebuild_board"
+
+	AuditQuarantineRelease = "quarantine.release"
+	AuditQuarantineDiscard = "quarantine.discard"
+	AuditFlagApprove       = "flag.approve"
+	AuditFlagReject        = "flag.reject"
+
+	AuditScoreVoid   = "score.void"
+	AuditScoreAdjust = "score.adjust"
+	AuditUserRemove  = "user.remove"
+	AuditUserBan     = "user.ban"
+	AuditUserUnban   = "user.unban"
+	AuditUserSetRole = "user.set_role"
+)
+
+// Audit target types.
+const (
+	AuditTargetUser       = "user"
+	AuditTargetGame       = "game"
+	AuditTargetScore      = "score"
+	AuditTargetQuarantine = "quarantine"
+	AuditTargetFlag       = "flag"
+)
+
+// AuditEntry records an administrative or security-relevant action. ActorID is
+// uuid.Nil for anonymous actions such as failed logins. RequestID and IP are
+// taken from the request context when the entry is written. Before and After
+// hold the target's state around the change and are stored as JSON.
+type AuditEntry struct {
+	Id         uuid.UUID      `json:"id"`
+	ActorID    uuid.UUID      `json:"actor_id"`
+	Action     string         `json:"action"`
+	TargetType string         `json:"target_type"`
+	TargetID   uuid.UUID      `json:"target_id"`
+	RequestID  string         `json:"request_id"`
+	IP         string         `json:"ip"`
+	Before     any            `json:"before,omitempty"`
+	After      any            `json:"after,omitempty"`
+	Details    map[string]any `json:"details,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+// AuditFilter selects audit entries; zero fields match everything. Entries are
+// returned newest first; Cursor, made by TimeCursor, continues after the last
+// entry of a page.
+type AuditFilter struct {
+	ActorID    *uuid.UUID
+	Action     string
+	TargetType string
+	TargetID   *uuid.UUID
+	From       *time.Time
+	To         *time.Time
+	Cursor     *Cursor
+	Limit      int
+}
+
+// AuditPage is one page of audit entries.
+type AuditPage struct {
+	Entries []AuditEntry `json:"entries"`
+	// NextCursor is empty on the last page.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
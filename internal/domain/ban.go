@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrUserBanned is returned when a ban forbids what the user tried to do.
+	ErrUserBanned = errors.New("user is banned")
+	// ErrBanNotFound is returned when no ban has the requested id.
+	ErrBanNotFound = errors.New("ban not found")
+	// ErrBanLifted is returned when a ban was already lifted or has expired.
+	ErrBanLifted = errors.New("ban already lifted")
+	// ErrInvalidBan is returned when a ban has no scopes, an unknown scope or a negative duration.
+	ErrInvalidBan = errors.New("invalid ban")
+)
+
+const (
+	// BanScopeLogin forbids logging in and using issued access tokens.
+	BanScopeLogin = "login"
+	// BanScopeSubmit forbids submitting scores.
+	BanScopeSubmit = "submit"
+	// BanScopeVisibility hides the user from every leaderboard; scores are still recorded.
+	BanScopeVisibility = "visibility"
+)
+
+// Ban suspends a user for the given scopes until ExpiresAt, or permanently when it is nil.
+type Ban struct {
+	Id        uuid.UUID  `json:"id" db:"id"`
+	UserID    uuid.UUID  `json:"user_id" db:"user_id"`
+	Scopes    []string   `json:"scopes" db:"-"`
+	Reason    string     `json:"reason" db:"reason"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	CreatedBy *uuid.UUID `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	LiftedAt  *time.Time `json:"lifted_at,omitempty" db:"lifted_at"`
+	LiftedBy  *uuid.UUID `json:"lifted_by,omitempty" db:"lifted_by"`
+}
+
+// Active reports whether the ban is in force at now.
+func (b Ban) Active(now time.Time) bool {
+	return b.LiftedAt == nil && (b.ExpiresAt == nil || b.ExpiresAt.After(now))
+}
+
+// Covers reports whether the ban applies to the scope.
+func (b Ban) Covers(scope string) bool {
+	for _, s := range b.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Err describes the ban as an error wrapping ErrUserBanned.
+func (b Ban) Err() error {
+	if b.ExpiresAt == nil {
+		return fmt.Errorf("%w permanently: %s", ErrUserBanned, b.Reason)
+	}
+	return fmt.Errorf("%w until %s: %s", ErrUserBanned, b.ExpiresAt.UTC().Format(time.RFC3339), b.Reason)
+}
+
+// Validate checks the ban's scopes.
+func (b Ban) Validate() error {
+	if len(b.Scopes) == 0 {
+		return errors.Join(ErrInvalidBan, errors.New("at least one scope is required"))
+	}
+	for _, scope := range b.Scopes {
+		switch scope {
+		case BanScopeLogin, BanScopeSubmit, BanScopeVisibility:
+		default:
+			return errors.Join(ErrInvalidBan, fmt.Errorf("unknown scope %q", scope))
+		}
+	}
+	if b.ExpiresAt != nil && !b.ExpiresAt.After(b.CreatedAt) {
+		return errors.Join(ErrInvalidBan, errors.New("expiry must be in the future"))
+	}
+	return nil
+}
+
+// GameTotal is a user's accepted score aggregates for a game.
+type GameTotal struct {
+	GameID uuid.UUID `db:"game_id"`
+	Sum    int64     `db:"sum"`
+	Best   int64     `db:"best"`
+}
+
+// PlayerTotal is one player's accepted score aggregates for a game, as needed
+// to rebuild its leaderboard.
+type PlayerTotal struct {
+	UserID uuid.UUID `db:"user_id"`
+	Region string    `db:"region"`
+	Sum    int64     `db:"sum"`
+	Best   int64     `db:"best"`
+}
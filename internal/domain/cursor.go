@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+	"github.com/google/uuid"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor points at the last row of a leaderboard page. The next page starts
+// right after (Score, Member) in leaderboard order, so rows do not shift under
+// the reader when other players' scores change.
+type Cursor struct {
+	Score  float64
+	Member string
+}
+
+// Encode returns the opaque, URL-safe representation of the cursor.
+func (c Cursor) Encode() string {
+	raw := strconv.FormatFloat(c.Score, 'g', -1, 64) + ":" + c.Member
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor parses a cursor produced by Cursor.Encode.
+func DecodeCursor(s string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	score, member, ok := strings.Cut(string(raw), ":")
+	if !ok || member == "" {
+		return Cursor{}, ErrInvalidCursor
+	}
+	value, err := strconv.ParseFloat(score, 64)
+	// Scores on a board are always finite; NaN would never compare as a position.
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return Cursor{Score: value, Member: member}, nil
+}
+
+// TimeCursor returns the cursor of a row in a list ordered by creation time and
+// id, newest first. Score holds the time in Unix microseconds, the precision of
+// Postgres timestamps, and Member the row id.
+func TimeCursor(at time.Time, id uuid.UUID) Cursor {
+	return Cursor{Score: float64(at.UnixMicro()), Member: id.String()}
+}
+
+// TimeKey returns the creation time and id of a cursor made by TimeCursor.
+func (c Cursor) TimeKey() (time.Time, uuid.UUID, error) {
+	id, err := uuid.Parse(c.Member)
+	if err != nil {
+		return time.Time{}, uuid.UUID{}, ErrInvalidCursor
+	}
+	return time.UnixMicro(int64(c.Score)).UTC(), id, nil
+}
+
+// PageRequest selects a leaderboard page either by offset or, when Cursor is
+// set, by position after the cursor.
+type PageRequest struct {
+	Offset int
+	Limit  int
+	Cursor *Cursor
+}
+
+// LeaderboardPage is one page of a leaderboard.
+type LeaderboardPage struct {
+	Users []LeaderboardUser `json:"users"`
+	// NextCursor is empty on the last page.
+	NextCursor string `json:"next_cursor,omitempty"`
+	// Total is the number of players on the board.
+	Total int64 `json:"total"`
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []Cursor{
+		{Score: 0, Member: "a"},
+		{Score: 1500, Member: "9b2f1c1e-8a47-4c55-a8c0-6c3a1f1b7d21"},
+		{Score: -42, Member: "player"},
+		{Score: 1.5e15, Member: "m:with:colons"},
+	}
+	for _, c := range tests {
+		got, err := DecodeCursor(c.Encode())
+		if err != nil || got != c {
+			t.Errorf("DecodeCursor(%+v.Encode()) = %+v, %v; want %+v", c, got, err, c)
+		}
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	encode := func(raw string) string { return base64.RawURLEncoding.EncodeToString([]byte(raw)) }
+	tests := []string{
+		"",
+		"not base64!",
+		encode("100"),
+		encode("100:"),
+		encode("abc:member"),
+		encode("NaN:member"),
+		encode("Inf:member"),
+		encode("+Inf:member"),
+		encode("-Inf:member"),
+	}
+	for _, s := range tests {
+		if _, err := DecodeCursor(s); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("DecodeCursor(%q) error = %v, want %v", s, err, ErrInvalidCursor)
+		}
+	}
+}
+
+func TestTimeCursor(t *testing.T) {
+	id := uuid.MustParse("9b2f1c1e-8a47-4c55-a8c0-6c3a1f1b7d21")
+	tests := []time.Time{
+		time.Date(2024, 3, 1, 12, 30, 45, 123456000, time.UTC),
+		// Nanoseconds are dropped, as by Postgres timestamps.
+		time.Date(2024, 3, 1, 12, 30, 45, 123456789, time.UTC),
+		time.Date(2024, 3, 1, 18, 30, 0, 0, time.FixedZone("UTC+6", 6*3600)),
+	}
+	for _, at := range tests {
+		c, err := DecodeCursor(TimeCursor(at, id).Encode())
+		if err != nil {
+			t.Fatalf("DecodeCursor: %v", err)
+		}
+		gotAt, gotID, err := c.TimeKey()
+		want := at.Truncate(time.Microsecond).UTC()
+		if err != nil || !gotAt.Equal(want) || gotAt.Location() != time.UTC || gotID != id {
+			t.Errorf("TimeKey() = %v, %v, %v; want %v, %v", gotAt, gotID, err, want, id)
+		}
+	}
+
+	if _, _, err := (Cursor{Score: 1, Member: "not-a-uuid"}).TimeKey(); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("TimeKey() error = %v, want %v", err, ErrInvalidCursor)
+	}
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrScoreNotFound is returned when no score history entry has the requested id.
+	ErrScoreNotFound = errors.New("score not found")
+	// ErrFlagNotFound is returned when no flag has the requested id.
+	ErrFlagNotFound = errors.New("flag not found")
+	// ErrFlagResolved is returned when a flag was already approved or rejected.
+	ErrFlagResolved = errors.New("flag already resolved")
+	// ErrAlreadyFlagged is returned when a player reports the same score twice.
+	ErrAlreadyFlagged = errors.New("score already flagged by this user")
+	// ErrScoreVoided is returned when a correction targets a score that no longer counts.
+	ErrScoreVoided = errors.New("score already voided or rejected")
+	// ErrAdjustmentUnsupported is returned when a score adjustment targets a game that keeps the best submission.
+	ErrAdjustmentUnsupported = errors.New("score adjustments are only supported on games aggregated by sum")
+)
+
+const (
+	// ScoreAccepted scores count towards the leaderboards.
+	ScoreAccepted = "accepted"
+	// ScoreRejected scores were removed from the leaderboards by a moderator.
+	ScoreRejected = "rejected"
+	// ScoreVoided scores were removed from the leaderboards by an admin.
+	ScoreVoided = "voided"
+
+	// ScoreKindSubmission entries were submitted by a game client.
+	ScoreKindSubmission = "submission"
+	// ScoreKindAdjustment entries are admin corrections of a player's game score.
+	ScoreKindAdjustment = "adjustment"
+)
+
+const (
+	// FlagSourcePlayer flags were reported by a player.
+	FlagSourcePlayer = "player"
+	// FlagSourceRule flags were raised by an automated rule.
+	FlagSourceRule = "rule"
+
+	// FlagPending flags wait for a moderator.
+	FlagPending = "pending"
+	// FlagApproved flags were reviewed and the score stands.
+	FlagApproved = "approved"
+	// FlagRejected flags were reviewed and the score was rejected.
+	FlagRejected = "rejected"
+)
+
+// ScoreRecord is an entry of the score history.
+type ScoreRecord struct {
+	Id        uuid.UUID `json:"id" db:"id"`
+	UserID    uuid.UUID `json:"user_id" db:"user_id"`
+	GameID    uuid.UUID `json:"game_id" db:"game_id"`
+	Score     int       `json:"score" db:"score"`
+	Kind      string    `json:"kind" db:"kind"`
+	Status    string    `json:"status" db:"status"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// ScoreFlag is a report that a score history entry may be illegitimate.
+// UserID, GameID and Score describe the flagged entry.
+type ScoreFlag struct {
+	Id          uuid.UUID  `json:"id" db:"id"`
+	ScoreID     uuid.UUID  `json:"score_id" db:"score_id"`
+	UserID      uuid.UUID  `json:"user_id" db:"user_id"`
+	GameID      uuid.UUID  `json:"game_id" db:"game_id"`
+	Score       int        `json:"score" db:"score"`
+	ReporterID  *uuid.UUID `json:"reporter_id,omitempty" db:"reporter_id"`
+	Source      string     `json:"source" db:"source"`
+	Reason      string     `json:"reason" db:"reason"`
+	Status      string     `json:"status" db:"status"`
+	ModeratorID *uuid.UUID `json:"moderator_id,omitempty" db:"moderator_id"`
+	DecidedAt   *time.Time `json:"decided_at,omitempty" db:"decided_at"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+}
@@ -1,10 +1,136 @@
 package domain
 
-import "github.com/google/uuid"
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrGameNotFound is returned when no game has the requested id.
+	ErrGameNotFound = errors.New("game not found")
+	// ErrGameArchived is returned when a write targets a game that has been archived.
+	ErrGameArchived = errors.New("game is archived")
+	// ErrGameConflict is returned when a game name or slug is already taken.
+	ErrGameConflict = errors.New("game name or slug already exists")
+	// ErrInvalidGame is returned when a game's slug or configuration is invalid.
+	ErrInvalidGame = errors.New("invalid game")
+	// ErrAggregationLocked is returned when an update changes the aggregation of a game that already has scores.
+	ErrAggregationLocked = errors.New("aggregation cannot change once the game has scores")
+	// ErrScoreOutOfBounds is returned when a submitted score violates the game's score bounds.
+	ErrScoreOutOfBounds = errors.New("score is out of the game's bounds")
+)
+
+const (
+	// AggregationSum adds every submission to the player's game score.
+	AggregationSum = "sum"
+	// AggregationMax keeps the player's best submission as their game score.
+	AggregationMax = "max"
+
+	// VisibilityPublic games are listed to players.
+	VisibilityPublic = "public"
+	// VisibilityHidden games are only listed to admins; their boards stay reachable by id.
+	VisibilityHidden = "hidden"
+
+	// DefaultSubmissionWindow is the rate limit window, in seconds, used when only MaxSubmissions is set.
+	DefaultSubmissionWindow = 60
+)
 
 // Game представляет игру.
 // swagger:model Game
 type Game struct {
-	Id   uuid.UUID `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
-	Name string    `json:"name" example:"Chess"`
+	Id          uuid.UUID  `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Name        string     `json:"name" example:"Chess"`
+	Slug        string     `json:"slug" example:"chess"`
+	Description string     `json:"description" example:"Classic chess, rated games only"`
+	Config      GameConfig `json:"config"`
+	// SigningSecret, when set, is the HMAC key game clients sign submissions with.
+	SigningSecret string     `json:"-"`
+	ArchivedAt    *time.Time `json:"archived_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// Signed reports whether the game only accepts signed score submissions.
+func (g Game) Signed() bool {
+	return g.SigningSecret != ""
+}
+
+// Archived reports whether the game has been retired.
+func (g Game) Archived() bool {
+	return g.ArchivedAt != nil
+}
+
+// GameConfig holds the per-game settings stored in the games.config column.
+// MinScore and MaxScore bound a single submission; the remaining fields
+// configure the anti-cheat rules and are disabled when zero.
+type GameConfig struct {
+	MinScore    *int64 `json:"min_score,omitempty"`
+	MaxScore    *int64 `json:"max_score,omitempty"`
+	Aggregation string `json:"aggregation,omitempty"`
+	Visibility  string `json:"visibility,omitempty"`
+
+	// MaxSubmissions is the number of submissions a player may make per SubmissionWindow seconds.
+	MaxSubmissions   int `json:"max_submissions,omitempty"`
+	SubmissionWindow int `json:"submission_window_seconds,omitempty"`
+	// MaxGainPerHour caps the sum of a player's submissions over the last hour.
+	MaxGainPerHour int64 `json:"max_gain_per_hour,omitempty"`
+	// OutlierZScore rejects submissions more than this many standard deviations
+	// above the mean of the game's recent submissions.
+	OutlierZScore float64 `json:"outlier_z_score,omitempty"`
+	// FlagZScore accepts submissions more than this many standard deviations
+	// above the mean but flags them for moderation.
+	FlagZScore float64 `json:"flag_z_score,omitempty"`
+}
+
+// WithDefaults fills the unset fields with their default values.
+func (c GameConfig) WithDefaults() GameConfig {
+	if c.Aggregation == "" {
+		c.Aggregation = AggregationSum
+	}
+	if c.Visibility == "" {
+		c.Visibility = VisibilityPublic
+	}
+	if c.MaxSubmissions > 0 && c.SubmissionWindow == 0 {
+		c.SubmissionWindow = DefaultSubmissionWindow
+	}
+	return c
+}
+
+// Validate checks that the configuration is consistent.
+func (c GameConfig) Validate() error {
+	if c.Aggregation != "" && c.Aggregation != AggregationSum && c.Aggregation != AggregationMax {
+		return errors.Join(ErrInvalidGame, errors.New("aggregation must be sum or max"))
+	}
+	if c.Visibility != "" && c.Visibility != VisibilityPublic && c.Visibility != VisibilityHidden {
+		return errors.Join(ErrInvalidGame, errors.New("visibility must be public or hidden"))
+	}
+	if c.MinScore != nil && c.MaxScore != nil && *c.MinScore > *c.MaxScore {
+		return errors.Join(ErrInvalidGame, errors.New("min_score must not exceed max_score"))
+	}
+	if c.MaxSubmissions < 0 || c.SubmissionWindow < 0 || c.MaxGainPerHour < 0 || c.OutlierZScore < 0 || c.FlagZScore < 0 {
+		return errors.Join(ErrInvalidGame, errors.New("anti-cheat limits must not be negative"))
+	}
+	return nil
+}
+
+// CheckScore returns ErrScoreOutOfBounds when the score is outside the configured bounds.
+func (c GameConfig) CheckScore(score int64) error {
+	if c.MinScore != nil && score < *c.MinScore {
+		return ErrScoreOutOfBounds
+	}
+	if c.MaxScore != nil && score > *c.MaxScore {
+		return ErrScoreOutOfBounds
+	}
+	return nil
+}
+
+var slugSeparators = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Slugify derives a URL-friendly slug from a game name.
+func Slugify(name string) string {
+	return strings.Trim(slugSeparators.ReplaceAllString(strings.ToLower(name), "-"), "-")
 }
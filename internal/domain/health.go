@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrShuttingDown = errors.New("instance is shutting down")
+
+const (
+	HealthOK   = "ok"
+	HealthFail = "fail"
+)
+
+// Readiness checks.
+const (
+	CheckPostgres   = "postgres"
+	CheckRedis      = "redis"
+	CheckMigrations = "migrations"
+	CheckShutdown   = "shutdown"
+)
+
+// HealthCheck is the outcome of one dependency check.
+type HealthCheck struct {
+	Name     string
+	Status   string
+	Error    string
+	Duration time.Duration
+}
+
+// HealthReport is ok only when every check is.
+type HealthReport struct {
+	Status string
+	Checks []HealthCheck
+}
@@ -1,6 +1,10 @@
 package domain
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"math"
+	"time"
+)
 
 // LeaderboardUser swagger:model LeaderboardUser
 // LeaderboardUser представляет запись пользователя в таблице лидеров.
@@ -15,3 +19,67 @@ type LeaderboardUser struct {
 	// example: 1
 	Rank int64 `json:"rank" example:"1"`
 }
+
+// RankStats describes a player's position on a single board.
+type RankStats struct {
+	// Rank is the 1-based position, -1 if the player has no score on the board.
+	Rank int64 `json:"rank" example:"48213"`
+	// Total is the number of players on the board.
+	Total int64 `json:"total" example:"690000"`
+}
+
+// Ranked reports whether the player has a score on the board.
+func (s RankStats) Ranked() bool {
+	return s.Rank > 0 && s.Total > 0
+}
+
+// Percentile returns the share of players ranked below the player, in percent.
+func (s RankStats) Percentile() float64 {
+	if !s.Ranked() {
+		return 0
+	}
+	return roundPercent(float64(s.Total-s.Rank) / float64(s.Total) * 100)
+}
+
+// TopPercent returns the share of players ranked at or above the player, in percent,
+// so a value of 7 reads as "top 7%".
+func (s RankStats) TopPercent() float64 {
+	if !s.Ranked() {
+		return 0
+	}
+	return roundPercent(float64(s.Rank) / float64(s.Total) * 100)
+}
+
+func roundPercent(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// PlayerRank holds a player's position on a board and,
+// when the player has a region, on the regional variant of that board.
+type PlayerRank struct {
+	Overall  RankStats `json:"overall"`
+	Region   string    `json:"region,omitempty" example:"kz"`
+	Regional RankStats `json:"regional"`
+}
+
+// ScoreBucket is one histogram bucket of a score distribution; bounds are inclusive.
+type ScoreBucket struct {
+	From  int64 `json:"from" example:"0"`
+	To    int64 `json:"to" example:"99"`
+	Count int64 `json:"count" example:"1500"`
+}
+
+// ScoreDistribution is a histogram of the scores on a game board.
+type ScoreDistribution struct {
+	Total   int64         `json:"total" example:"690000"`
+	Min     int64         `json:"min" example:"0"`
+	Max     int64         `json:"max" example:"99999"`
+	Buckets []ScoreBucket `json:"buckets"`
+}
+
+// BoardVersion identifies the state of a game board. Version is bumped on every
+// write to the board, so it changes whenever a listing of the board may change.
+type BoardVersion struct {
+	Version   int64
+	UpdatedAt time.Time
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "testing"
+
+func TestRankStatsPercent(t *testing.T) {
+	tests := []struct {
+		stats      RankStats
+		percentile float64
+		top        float64
+	}{
+		{RankStats{Rank: 1, Total: 1}, 0, 100},
+		{RankStats{Rank: 1, Total: 4}, 75, 25},
+		{RankStats{Rank: 4, Total: 4}, 0, 100},
+		{RankStats{Rank: 1, Total: 3}, 66.67, 33.33},
+		{RankStats{Rank: 48213, Total: 690000}, 93.01, 6.99},
+		{RankStats{Rank: -1, Total: 10}, 0, 0},
+		{RankStats{Rank: 0, Total: 0}, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.stats.Percentile(); got != tt.percentile {
+			t.Errorf("%+v.Percentile() = %v, want %v", tt.stats, got, tt.percentile)
+		}
+		if got := tt.stats.TopPercent(); got != tt.top {
+			t.Errorf("%+v.TopPercent() = %v, want %v", tt.stats, got, tt.top)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidBucket = errors.New("bucket must be hour, day or week")
+	ErrInvalidRange  = errors.New("from must be before to")
+)
+
+// Progress bucket sizes; they are Postgres date_trunc fields.
+const (
+	BucketHour = "hour"
+	BucketDay  = "day"
+	BucketWeek = "week"
+)
+
+// ProgressQuery selects a player's progress on a game board between From
+// (inclusive) and To (exclusive), downsampled to Bucket.
+type ProgressQuery struct {
+	UserID uuid.UUID
+	GameID uuid.UUID
+	Bucket string
+	From   time.Time
+	To     time.Time
+}
+
+// DefaultProgressSpan returns the period charted when no start is given, sized
+// so that every bucket yields a readable number of points.
+func DefaultProgressSpan(bucket string) time.Duration {
+	switch bucket {
+	case BucketHour:
+		return 7 * 24 * time.Hour
+	case BucketWeek:
+		return 365 * 24 * time.Hour
+	default:
+		return 90 * 24 * time.Hour
+	}
+}
+
+// ProgressPoint summarises the snapshots of one bucket: the best rank reached,
+// the highest score and the rank and score at the end of the bucket.
+type ProgressPoint struct {
+	At        time.Time `json:"at" db:"at"`
+	BestRank  int64     `json:"best_rank" db:"best_rank"`
+	Rank      int64     `json:"rank" db:"rank"`
+	BestScore int64     `json:"best_score" db:"best_score"`
+	Score     int64     `json:"score" db:"score"`
+}
+
+// ProgressSeries is a player's progress on a game board, oldest bucket first.
+type ProgressSeries struct {
+	ProgressQuery
+	Points []ProgressPoint
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrScoreQuarantined is returned when a submission broke an anti-cheat rule
+	// and was held for review instead of reaching the leaderboards.
+	ErrScoreQuarantined = errors.New("score submission held for review")
+	// ErrQuarantineNotFound is returned when no quarantined submission has the requested id.
+	ErrQuarantineNotFound = errors.New("quarantined score not found")
+	// ErrQuarantineResolved is returned when a quarantined submission was already released or discarded.
+	ErrQuarantineResolved = errors.New("quarantined score already resolved")
+)
+
+const (
+	QuarantinePending   = "pending"
+	QuarantineReleased  = "released"
+	QuarantineDiscarded = "discarded"
+)
+
+// Anti-cheat rule names recorded with quarantined submissions.
+const (
+	RuleScoreBounds    = "score_bounds"
+	RuleSubmissionRate = "submission_rate"
+	RuleHourlyGain     = "hourly_gain"
+	RuleOutlier        = "outlier"
+)
+
+// RuleViolation describes the anti-cheat rule a submission broke.
+type RuleViolation struct {
+	Rule   string
+	Reason string
+}
+
+// QuarantinedScore is a submission held for review by the anti-cheat rules.
+type QuarantinedScore struct {
+	Id        uuid.UUID `json:"id" db:"id"`
+	UserID    uuid.UUID `json:"user_id" db:"user_id"`
+	GameID    uuid.UUID `json:"game_id" db:"game_id"`
+	Score     int       `json:"score" db:"score"`
+	Rule      string    `json:"rule" db:"rule"`
+	Reason    string    `json:"reason" db:"reason"`
+	Status    string    `json:"status" db:"status"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	// ScoreID is the score history entry of a released submission.
+	ScoreID *uuid.UUID `json:"score_id,omitempty" db:"score_id"`
+}
+
+// ScoreStats summarises a sample of a game's submissions.
+type ScoreStats struct {
+	Count  int64   `db:"count"`
+	Mean   float64 `db:"mean"`
+	StdDev float64 `db:"stddev"`
+}
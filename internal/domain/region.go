@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// ErrInvalidRegion is returned when a region code does not match the expected format.
+var ErrInvalidRegion = errors.New("invalid region code")
+
+// regionPattern accepts country codes ("kz", "us") and broader regions ("eu", "eu-west").
+var regionPattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})?$`)
+
+// NormalizeRegion lower-cases and validates a region code.
+// An empty string means "no region" and is returned as is.
+func NormalizeRegion(region string) (string, error) {
+	region = strings.ToLower(strings.TrimSpace(region))
+	if region == "" {
+		return "", nil
+	}
+	if !regionPattern.MatchString(region) {
+		return "", ErrInvalidRegion
+	}
+	return region, nil
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizeRegion(t *testing.T) {
+	tests := []struct {
+		region string
+		want   string
+		err    error
+	}{
+		{"", "", nil},
+		{"   ", "", nil},
+		{"kz", "kz", nil},
+		{" EU ", "eu", nil},
+		{"usa", "usa", nil},
+		{"eu-west", "eu-west", nil},
+		{"EU-West2", "eu-west2", nil},
+		{"e", "", ErrInvalidRegion},
+		{"europe", "", ErrInvalidRegion},
+		{"eu-", "", ErrInvalidRegion},
+		{"eu-w", "", ErrInvalidRegion},
+		{"eu-westernmost", "", ErrInvalidRegion},
+		{"eu_west", "", ErrInvalidRegion},
+		{"e1", "", ErrInvalidRegion},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeRegion(tt.region)
+		if !errors.Is(err, tt.err) || got != tt.want {
+			t.Errorf("NormalizeRegion(%q) = %q, %v; want %q, %v", tt.region, got, err, tt.want, tt.err)
+		}
+	}
+}
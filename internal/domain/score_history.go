@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ScoreHistoryFilter selects a user's score history entries. GameID, From and
+// To are optional; From is inclusive and To exclusive. Entries are returned
+// newest first; Cursor, made by TimeCursor, continues after the last entry of a page.
+type ScoreHistoryFilter struct {
+	UserID uuid.UUID
+	GameID *uuid.UUID
+	From   *time.Time
+	To     *time.Time
+	Cursor *Cursor
+	Limit  int
+}
+
+// ScoreSummary aggregates a user's accepted scores for one game. Best, Average
+// and Count cover submissions; Total also includes admin adjustments, as the
+// leaderboards of games aggregated by sum do.
+type ScoreSummary struct {
+	GameID  uuid.UUID `json:"game_id" db:"game_id"`
+	Best    int64     `json:"best" db:"best"`
+	Average float64   `json:"average" db:"average"`
+	Count   int64     `json:"count" db:"count"`
+	Total   int64     `json:"total" db:"total"`
+}
+
+// ScoreHistoryPage is one page of a user's score history with the summary of
+// every entry matching the filter, not only those on the page.
+type ScoreHistoryPage struct {
+	Scores  []ScoreRecord  `json:"scores"`
+	Summary []ScoreSummary `json:"summary"`
+	// NextCursor is empty on the last page.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
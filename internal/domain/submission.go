@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInvalidSignature is returned when a signed game receives a submission
+	// without a signature or with one that does not match.
+	ErrInvalidSignature = errors.New("invalid score signature")
+	// ErrStaleSubmission is returned when a signed submission's timestamp is too far from the server clock.
+	ErrStaleSubmission = errors.New("score submission timestamp is stale")
+	// ErrNonceReused is returned when a signed submission replays a nonce already used for the game.
+	ErrNonceReused = errors.New("score submission nonce already used")
+)
+
+// SubmissionMaxSkew is how far a signed submission's timestamp may be from the server clock.
+const SubmissionMaxSkew = 5 * time.Minute
+
+// ScoreSubmission is a score sent by a game client. Nonce, Timestamp and
+// Signature are only checked for games that have a signing secret.
+type ScoreSubmission struct {
+	UserID uuid.UUID
+	GameID uuid.UUID
+	Score  int
+	Nonce  string
+	// Timestamp is the submission time in Unix seconds.
+	Timestamp int64
+	// Signature is the hex-encoded HMAC-SHA256 of Payload under the game's secret.
+	Signature string
+}
+
+// Payload returns the signed message: "user|game|score|nonce|timestamp".
+func (s ScoreSubmission) Payload() string {
+	return fmt.Sprintf("%s|%s|%d|%s|%d", s.UserID, s.GameID, s.Score, s.Nonce, s.Timestamp)
+}
+
+// Sign returns the hex-encoded signature of the submission under secret.
+func (s ScoreSubmission) Sign(secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(s.Payload()))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the submission's signature under secret and its timestamp against now.
+func (s ScoreSubmission) Verify(secret string, now time.Time) error {
+	signature, err := hex.DecodeString(s.Signature)
+	if err != nil || s.Nonce == "" {
+		return ErrInvalidSignature
+	}
+	expected, _ := hex.DecodeString(s.Sign(secret))
+	if !hmac.Equal(signature, expected) {
+		return ErrInvalidSignature
+	}
+	skew := now.Sub(time.Unix(s.Timestamp, 0))
+	if skew > SubmissionMaxSkew || skew < -SubmissionMaxSkew {
+		return ErrStaleSubmission
+	}
+	return nil
+}
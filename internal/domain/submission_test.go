@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestScoreSubmissionVerify(t *testing.T) {
+	const secret = "s3cret"
+	now := time.Unix(1_700_000_000, 0)
+	signed := func(edit func(*ScoreSubmission)) ScoreSubmission {
+		s := ScoreSubmission{
+			UserID:    uuid.MustParse("9b2f1c1e-8a47-4c55-a8c0-6c3a1f1b7d21"),
+			GameID:    uuid.MustParse("3d0c2e55-1f0e-4f43-9f0a-2b6f2f7c9e10"),
+			Score:     1500,
+			Nonce:     "n-1",
+			Timestamp: now.Unix(),
+		}
+		s.Signature = s.Sign(secret)
+		if edit != nil {
+			edit(&s)
+		}
+		return s
+	}
+
+	tests := []struct {
+		name string
+		sub  ScoreSubmission
+		want error
+	}{
+		{"valid", signed(nil), nil},
+		{"upper-case hex", signed(func(s *ScoreSubmission) { s.Signature = strings.ToUpper(s.Signature) }), nil},
+		{"oldest allowed", signed(func(s *ScoreSubmission) {
+			s.Timestamp = now.Add(-SubmissionMaxSkew).Unix()
+			s.Signature = s.Sign(secret)
+		}), nil},
+		{"too old", signed(func(s *ScoreSubmission) {
+			s.Timestamp = now.Add(-SubmissionMaxSkew - time.Second).Unix()
+			s.Signature = s.Sign(secret)
+		}), ErrStaleSubmission},
+		{"too far ahead", signed(func(s *ScoreSubmission) {
+			s.Timestamp = now.Add(SubmissionMaxSkew + time.Second).Unix()
+			s.Signature = s.Sign(secret)
+		}), ErrStaleSubmission},
+		{"changed score", signed(func(s *ScoreSubmission) { s.Score++ }), ErrInvalidSignature},
+		{"other secret", signed(func(s *ScoreSubmission) { s.Signature = s.Sign("other") }), ErrInvalidSignature},
+		{"missing signature", signed(func(s *ScoreSubmission) { s.Signature = "" }), ErrInvalidSignature},
+		{"not hex", signed(func(s *ScoreSubmission) { s.Signature = "zz" }), ErrInvalidSignature},
+		{"missing nonce", signed(func(s *ScoreSubmission) {
+			s.Nonce = ""
+			s.Signature = s.Sign(secret)
+		}), ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.sub.Verify(secret, now); !errors.Is(err, tt.want) {
+				t.Fatalf("Verify() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,28 @@
 package domain
 
-import "github.com/google/uuid"
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrForbidden is returned when the user lacks the role an operation requires.
+	ErrForbidden = errors.New("forbidden")
+	// ErrUserNotFound is returned when no user has the requested id or username.
+	ErrUserNotFound = errors.New("user not found")
+	// ErrInvalidRole is returned when a role is not one of the known roles.
+	ErrInvalidRole = errors.New("role must be player, moderator or admin")
+)
+
+const (
+	// RolePlayer is the role of every registered user.
+	RolePlayer = "player"
+	// RoleModerator may review flagged scores.
+	RoleModerator = "moderator"
+	// RoleAdmin may do everything a moderator can.
+	RoleAdmin = "admin"
+)
 
 // User represents an application user.
 type User struct {
@@ -8,4 +30,25 @@ type User struct {
 	Username string    `json:"username" db:"username"`
 	Email    string    `json:"email" db:"email"`
 	Password string    `json:"password" db:"password"`
+	Region   string    `json:"region" db:"region"`
+	Role     string    `json:"role" db:"role"`
+}
+
+// ValidRole reports whether role is one of the known roles.
+func ValidRole(role string) bool {
+	switch role {
+	case RolePlayer, RoleModerator, RoleAdmin:
+		return true
+	}
+	return false
+}
+
+// HasRole reports whether the user has one of the given roles.
+func (u User) HasRole(roles ...string) bool {
+	for _, role := range roles {
+		if u.Role == role {
+			return true
+		}
+	}
+	return false
 }
@@ -9,9 +9,6 @@ import (
 )
 
 const (
-	accessTTL  = 30 * time.Minute
-	refreshTTL = 7 * 24 * time.Hour
-
 	accessTokenType  = "access"
 	refreshTokenType = "refresh"
 
@@ -21,12 +18,16 @@ const (
 type TokenManager struct {
 	accessKey  []byte
 	refreshKey []byte
+	accessTTL  time.Duration
+	refreshTTL time.Duration
 }
 
-func NewTokenManager(accessKey, refreshKey string) *TokenManager {
+func NewTokenManager(accessKey, refreshKey string, accessTTL, refreshTTL time.Duration) *TokenManager {
 	return &TokenManager{
 		accessKey:  []byte(accessKey),
 		refreshKey: []byte(refreshKey),
+		accessTTL:  accessTTL,
+		refreshTTL: refreshTTL,
 	}
 }
 
@@ -41,11 +42,11 @@ type Claims struct {
 //////////////////////
 
 func (m *TokenManager) NewAccessToken(userID string) (string, error) {
-	return m.newToken(userID, accessTokenType, accessTTL, m.accessKey)
+	return m.newToken(userID, accessTokenType, m.accessTTL, m.accessKey)
 }
 
 func (m *TokenManager) NewRefreshToken(userID string) (string, error) {
-	return m.newToken(userID, refreshTokenType, refreshTTL, m.refreshKey)
+	return m.newToken(userID, refreshTokenType, m.refreshTTL, m.refreshKey)
 }
 
 func (m *TokenManager) newToken(
@@ -3,6 +3,8 @@ package logger
 import (
 	"OnlineLeadership/internal/interfaces/http/middleware"
 	"context"
+	"go.opentelemetry.io/otel/trace"
+	"io"
 	"log/slog"
 	"os"
 )
@@ -11,17 +13,29 @@ type SlogLogger struct {
 	log *slog.Logger
 }
 
-func New(env string) *SlogLogger {
-	var handler slog.Handler
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// New logs at the given level (debug, info, warn or error) as text or JSON.
+func New(level, format string) *SlogLogger {
+	return NewWithWriter(os.Stdout, level, format)
+}
+
+// NewWithWriter is New writing to w instead of stdout.
+func NewWithWriter(w io.Writer, level, format string) *SlogLogger {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		lvl = slog.LevelInfo
+	}
+	opts := &slog.HandlerOptions{Level: lvl}
 
-	if env == "prod" {
-		handler = slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-			Level: slog.LevelInfo,
-		})
+	var handler slog.Handler
+	if format == FormatJSON {
+		handler = slog.NewJSONHandler(w, opts)
 	} else {
-		handler = slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
-			Level: slog.LevelDebug,
-		})
+		handler = slog.NewTextHandler(w, opts)
 	}
 	return &SlogLogger{
 		log: slog.New(handler),
@@ -33,13 +47,17 @@ func (l *SlogLogger) withContext(ctx context.Context) *slog.Logger {
 		return l.log
 	}
 
+	var attrs []any
 	if reqID, ok := ctx.Value(middleware.RequestIDKey).(string); ok {
-		return l.log.With(
-			slog.String("request_id", reqID),
-		)
+		attrs = append(attrs, slog.String("request_id", reqID))
 	}
-
-	return l.log
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		attrs = append(attrs, slog.String("trace_id", sc.TraceID().String()))
+	}
+	if len(attrs) == 0 {
+		return l.log
+	}
+	return l.log.With(attrs...)
 }
 
 func (l *SlogLogger) Info(ctx context.Context, msg string, args ...any) {
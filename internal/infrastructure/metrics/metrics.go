@@ -0,0 +1,153 @@
+// Package metrics defines the Prometheus metrics of the service and the
+// instrumentation of its HTTP, Redis and Postgres calls.
+package metrics
+
+import (
+	"OnlineLeadership/internal/domain"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const namespace = "leaderboard"
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by method, route and status.",
+	}, []string{"method", "route", "status"})
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method, route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	submissions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "score_submissions_total",
+		Help:      "Score submissions for existing games by game and result (accepted, quarantined or rejected).",
+	}, []string{"game_id", "result"})
+
+	rateLimited = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_rate_limited_total",
+		Help:      "HTTP requests rejected by the rate limiter by route.",
+	}, []string{"route"})
+
+	redisDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "redis_command_duration_seconds",
+		Help:      "Redis command and pipeline latency by command.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+	}, []string{"command"})
+	redisErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "redis_errors_total",
+		Help:      "Failed Redis commands by command; missing keys are not counted.",
+	}, []string{"command"})
+
+	postgresDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "postgres_call_duration_seconds",
+		Help:      "Postgres call latency by operation (query, exec, begin, commit, rollback).",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation"})
+	postgresErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "postgres_errors_total",
+		Help:      "Failed Postgres calls by operation; empty results are not counted.",
+	}, []string{"operation"})
+
+	registry = prometheus.NewRegistry()
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests, httpDuration, rateLimited,
+		submissions,
+		redisDuration, redisErrors,
+		postgresDuration, postgresErrors,
+	)
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// ObserveRequest records a served HTTP request. Route is the matched route
+// pattern, never the raw path, to keep the number of series bounded.
+func ObserveRequest(method string, route string, status int, elapsed time.Duration) {
+	code := strconv.Itoa(status)
+	httpRequests.WithLabelValues(method, route, code).Inc()
+	httpDuration.WithLabelValues(method, route, code).Observe(elapsed.Seconds())
+}
+
+// ObserveRateLimited records a request rejected by the rate limiter.
+func ObserveRateLimited(route string) {
+	rateLimited.WithLabelValues(route).Inc()
+}
+
+// ObserveSubmission records the outcome of a score submission for an existing game.
+func ObserveSubmission(gameID string, err error) {
+	result := "accepted"
+	switch {
+	case errors.Is(err, domain.ErrScoreQuarantined):
+		result = "quarantined"
+	case err != nil:
+		result = "rejected"
+	}
+	submissions.WithLabelValues(gameID, result).Inc()
+}
+
+// RegisterDBStats exports the connection pool statistics of db.
+func RegisterDBStats(db *sql.DB, name string) {
+	registry.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// BoardSizes returns the number of players per leaderboard, keyed by board name.
+type BoardSizes func(ctx context.Context) (map[string]int64, error)
+
+// RegisterBoardSizes exports the leaderboard sizes returned by sizes, which is
+// called on every scrape with the given timeout.
+func RegisterBoardSizes(sizes BoardSizes, timeout time.Duration) {
+	registry.MustRegister(&boardCollector{sizes: sizes, timeout: timeout})
+}
+
+var boardSizeDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "board_players"),
+	"Players on a leaderboard; board is \"global\" or a game id.",
+	[]string{"board"}, nil,
+)
+
+type boardCollector struct {
+	sizes   BoardSizes
+	timeout time.Duration
+}
+
+func (c *boardCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- boardSizeDesc
+}
+
+func (c *boardCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	sizes, err := c.sizes(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(boardSizeDesc, err)
+		return
+	}
+	for board, size := range sizes {
+		ch <- prometheus.MustNewConstMetric(boardSizeDesc, prometheus.GaugeValue, float64(size), board)
+	}
+}
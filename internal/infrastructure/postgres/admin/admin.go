@@ -5,11 +5,48 @@ import (
 	"OnlineLeadership/internal/infrastructure/logger"
 	"OnlineLeadership/internal/infrastructure/postgres"
 	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"time"
 )
 
+const gameColumns = `id, name, slug, description, config, signing_secret, archived_at, created_at, updated_at`
+
+// gameRow mirrors a row of the games table; config is kept as raw JSON.
+type gameRow struct {
+	Id          uuid.UUID  `db:"id"`
+	Name        string     `db:"name"`
+	Slug        string     `db:"slug"`
+	Description string     `db:"description"`
+	Config      []byte     `db:"config"`
+	Secret      string     `db:"signing_secret"`
+	ArchivedAt  *time.Time `db:"archived_at"`
+	CreatedAt   time.Time  `db:"created_at"`
+	UpdatedAt   time.Time  `db:"updated_at"`
+}
+
+func (g gameRow) toDomain() (domain.Game, error) {
+	game := domain.Game{
+		Id:            g.Id,
+		Name:          g.Name,
+		Slug:          g.Slug,
+		Description:   g.Description,
+		ArchivedAt:    g.ArchivedAt,
+		SigningSecret: g.Secret,
+		CreatedAt:     g.CreatedAt,
+		UpdatedAt:     g.UpdatedAt,
+	}
+	if err := json.Unmarshal(g.Config, &game.Config); err != nil {
+		return domain.Game{}, fmt.Errorf("decode config of game %s: %w", g.Id, err)
+	}
+	return game, nil
+}
+
 type RepositoryAdmin struct {
 	db  *sqlx.DB
 	log *logger.SlogLogger
@@ -19,23 +56,120 @@ func NewAdminRepository(db *sqlx.DB, log *logger.SlogLogger) *RepositoryAdmin {
 	return &RepositoryAdmin{db: db, log: log}
 }
 
-func (r *RepositoryAdmin) Create(ctx context.Context, name string) (uuid.UUID, error) {
-	var id uuid.UUID
-	query := fmt.Sprintf(`INSERT INTO %s (name) VALUES ($1) RETURNING id`, postgres.Games)
-	row := r.db.QueryRowContext(ctx, query, name)
-	err := row.Scan(&id)
+func (r *RepositoryAdmin) Create(ctx context.Context, game domain.Game) (uuid.UUID, error) {
+	config, err := json.Marshal(game.Config)
 	if err != nil {
 		return uuid.UUID{}, err
 	}
+	var id uuid.UUID
+	query := fmt.Sprintf(`INSERT INTO %s (name, slug, description, config) VALUES ($1, $2, $3, $4) RETURNING id`, postgres.Games)
+	row := r.db.QueryRowContext(ctx, query, game.Name, game.Slug, game.Description, config)
+	err = row.Scan(&id)
+	if err != nil {
+		return uuid.UUID{}, mapGameError(err)
+	}
 	return id, nil
 }
 func (r *RepositoryAdmin) GetGames(ctx context.Context) ([]domain.Game, error) {
-	var games []domain.Game
-	query := fmt.Sprintf(`SELECT * FROM %s`, postgres.Games)
-	err := r.db.Select(&games, query)
+	var rows []gameRow
+	query := fmt.Sprintf(`SELECT %s FROM %s ORDER BY created_at, name`, gameColumns, postgres.Games)
+	err := r.db.SelectContext(ctx, &rows, query)
 	if err != nil {
 		r.log.Error(ctx, "repository get games error :", err.Error())
 		return []domain.Game{}, err
 	}
+	games := make([]domain.Game, 0, len(rows))
+	for _, row := range rows {
+		game, err := row.toDomain()
+		if err != nil {
+			return []domain.Game{}, err
+		}
+		games = append(games, game)
+	}
 	return games, nil
 }
+
+func (r *RepositoryAdmin) GetGame(ctx context.Context, id uuid.UUID) (domain.Game, error) {
+	var row gameRow
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE id = $1`, gameColumns, postgres.Games)
+	err := r.db.GetContext(ctx, &row, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Game{}, domain.ErrGameNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "repository get game error :", err.Error())
+		return domain.Game{}, err
+	}
+	return row.toDomain()
+}
+
+func (r *RepositoryAdmin) UpdateGame(ctx context.Context, game domain.Game) error {
+	config, err := json.Marshal(game.Config)
+	if err != nil {
+		return err
+	}
+	query := fmt.Sprintf(`
+		UPDATE %s SET name = $2, slug = $3, description = $4, config = $5, updated_at = now()
+		WHERE id = $1`, postgres.Games)
+	res, err := r.db.ExecContext(ctx, query, game.Id, game.Name, game.Slug, game.Description, config)
+	if err != nil {
+		return mapGameError(err)
+	}
+	return expectOneRow(res)
+}
+
+// SetSigningSecret replaces the game's signing secret; an empty secret turns signing off.
+func (r *RepositoryAdmin) SetSigningSecret(ctx context.Context, id uuid.UUID, secret string) error {
+	query := fmt.Sprintf(`UPDATE %s SET signing_secret = $2, updated_at = now() WHERE id = $1`, postgres.Games)
+	res, err := r.db.ExecContext(ctx, query, id, secret)
+	if err != nil {
+		r.log.Error(ctx, "repository set signing secret error :", err.Error())
+		return err
+	}
+	return expectOneRow(res)
+}
+
+// SetArchived archives or restores a game. Archiving keeps the row, its
+// history and its leaderboards; it only retires the game.
+func (r *RepositoryAdmin) SetArchived(ctx context.Context, id uuid.UUID, archived bool) error {
+	query := fmt.Sprintf(`
+		UPDATE %s SET archived_at = CASE WHEN $2 THEN COALESCE(archived_at, now()) END, updated_at = now()
+		WHERE id = $1`, postgres.Games)
+	res, err := r.db.ExecContext(ctx, query, id, archived)
+	if err != nil {
+		r.log.Error(ctx, "repository archive game error :", err.Error())
+		return err
+	}
+	return expectOneRow(res)
+}
+
+// DeleteGame removes the game row; its score history is removed by cascade.
+func (r *RepositoryAdmin) DeleteGame(ctx context.Context, id uuid.UUID) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, postgres.Games)
+	res, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		r.log.Error(ctx, "repository delete game error :", err.Error())
+		return err
+	}
+	return expectOneRow(res)
+}
+
+func expectOneRow(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrGameNotFound
+	}
+	return nil
+}
+
+// mapGameError translates unique violations on name or slug into domain.ErrGameConflict.
+func mapGameError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return domain.ErrGameConflict
+	}
+	return err
+}
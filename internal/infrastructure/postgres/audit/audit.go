@@ -0,0 +1,176 @@
+package audit
+
+import (
+	"OnlineLeadership/internal/domain"
+	"OnlineLeadership/internal/infrastructure/logger"
+	"OnlineLeadership/internal/infrastructure/postgres"
+	"OnlineLeadership/internal/interfaces/http/middleware"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"strings"
+	"time"
+)
+
+const auditColumns = `id, actor_id, action, target_type, target_id, request_id, ip, before, after, details, created_at`
+
+// auditRow mirrors a row of the audit_log table; the JSON columns are decoded into plain values.
+type auditRow struct {
+	Id         uuid.UUID     `db:"id"`
+	ActorID    uuid.NullUUID `db:"actor_id"`
+	Action     string        `db:"action"`
+	TargetType string        `db:"target_type"`
+	TargetID   uuid.NullUUID `db:"target_id"`
+	RequestID  string        `db:"request_id"`
+	IP         string        `db:"ip"`
+	Before     []byte        `db:"before"`
+	After      []byte        `db:"after"`
+	Details    []byte        `db:"details"`
+	CreatedAt  time.Time     `db:"created_at"`
+}
+
+func (r auditRow) toDomain() (domain.AuditEntry, error) {
+	entry := domain.AuditEntry{
+		Id:         r.Id,
+		ActorID:    r.ActorID.UUID,
+		Action:     r.Action,
+		TargetType: r.TargetType,
+		TargetID:   r.TargetID.UUID,
+		RequestID:  r.RequestID,
+		IP:         r.IP,
+		CreatedAt:  r.CreatedAt,
+	}
+	for _, col := range []struct {
+		raw []byte
+		dst any
+	}{{r.Before, &entry.Before}, {r.After, &entry.After}, {r.Details, &entry.Details}} {
+		if len(col.raw) == 0 {
+			continue
+		}
+		if err := json.Unmarshal(col.raw, col.dst); err != nil {
+			return domain.AuditEntry{}, err
+		}
+	}
+	return entry, nil
+}
+
+type Repository struct {
+	db  *sqlx.DB
+	log *logger.SlogLogger
+}
+
+func NewAuditRepository(db *sqlx.DB, log *logger.SlogLogger) *Repository {
+	return &Repository{db: db, log: log}
+}
+
+// Record appends the entry. The request id and client address are taken from ctx
+// when the entry does not carry them.
+func (r *Repository) Record(ctx context.Context, entry domain.AuditEntry) error {
+	if entry.RequestID == "" {
+		entry.RequestID, _ = ctx.Value(middleware.RequestIDKey).(string)
+	}
+	if entry.IP == "" {
+		entry.IP, _ = ctx.Value(middleware.ClientIPKey).(string)
+	}
+	details, err := json.Marshal(entry.Details)
+	if err != nil {
+		return err
+	}
+	if entry.Details == nil {
+		details = []byte(`{}`)
+	}
+	before, err := jsonOrNull(entry.Before)
+	if err != nil {
+		return err
+	}
+	after, err := jsonOrNull(entry.After)
+	if err != nil {
+		return err
+	}
+	query := fmt.Sprintf(`INSERT INTO %s (actor_id, action, target_type, target_id, request_id, ip, before, after, details)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`, postgres.AuditLog)
+	_, err = r.db.ExecContext(ctx, query,
+		nullUUID(entry.ActorID), entry.Action, entry.TargetType, nullUUID(entry.TargetID),
+		entry.RequestID, entry.IP, before, after, details)
+	if err != nil {
+		r.log.Error(ctx, "repository record audit entry error", err.Error())
+		return err
+	}
+	return nil
+}
+
+// List returns the entries matching the filter, newest first. It fetches one
+// entry beyond the limit to tell whether another page follows.
+func (r *Repository) List(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	var (
+		where []string
+		args  []any
+	)
+	add := func(cond string, arg any) {
+		args = append(args, arg)
+		where = append(where, fmt.Sprintf(cond, len(args)))
+	}
+	if filter.ActorID != nil {
+		add("actor_id = $%d", *filter.ActorID)
+	}
+	if filter.Action != "" {
+		add("action = $%d", filter.Action)
+	}
+	if filter.TargetType != "" {
+		add("target_type = $%d", filter.TargetType)
+	}
+	if filter.TargetID != nil {
+		add("target_id = $%d", *filter.TargetID)
+	}
+	if filter.From != nil {
+		add("created_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		add("created_at < $%d", *filter.To)
+	}
+	if filter.Cursor != nil {
+		at, id, err := filter.Cursor.TimeKey()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, at, id)
+		where = append(where, fmt.Sprintf("(created_at, id) < ($%d, $%d)", len(args)-1, len(args)))
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM %s`, auditColumns, postgres.AuditLog)
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	args = append(args, filter.Limit+1)
+	query += fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d", len(args))
+
+	var rows []auditRow
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		r.log.Error(ctx, "repository list audit entries error", err.Error())
+		return nil, err
+	}
+	entries := make([]domain.AuditEntry, 0, len(rows))
+	for _, row := range rows {
+		entry, err := row.toDomain()
+		if err != nil {
+			r.log.Error(ctx, "repository decode audit entry error", err.Error())
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func nullUUID(id uuid.UUID) uuid.NullUUID {
+	return uuid.NullUUID{UUID: id, Valid: id != uuid.Nil}
+}
+
+// jsonOrNull encodes v as JSON, keeping a nil value as SQL NULL.
+func jsonOrNull(v any) (any, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return json.Marshal(v)
+}
@@ -0,0 +1,111 @@
+package ban
+
+import (
+	"OnlineLeadership/internal/domain"
+	"OnlineLeadership/internal/infrastructure/logger"
+	"OnlineLeadership/internal/infrastructure/postgres"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"time"
+)
+
+const banColumns = `id, user_id, scopes, reason, expires_at, created_by, created_at, lifted_at, lifted_by`
+
+// banRow mirrors a row of the bans table; scopes is a Postgres text array.
+type banRow struct {
+	domain.Ban
+	Scopes pq.StringArray `db:"scopes"`
+}
+
+func (b banRow) toDomain() domain.Ban {
+	ban := b.Ban
+	ban.Scopes = b.Scopes
+	return ban
+}
+
+type Repository struct {
+	db  *sqlx.DB
+	log *logger.SlogLogger
+}
+
+func NewBanRepository(db *sqlx.DB, log *logger.SlogLogger) *Repository {
+	return &Repository{db: db, log: log}
+}
+
+func (r *Repository) Create(ctx context.Context, ban domain.Ban) (uuid.UUID, error) {
+	var id uuid.UUID
+	query := fmt.Sprintf(`INSERT INTO %s (user_id, scopes, reason, expires_at, created_by) VALUES ($1, $2, $3, $4, $5) RETURNING id`, postgres.Bans)
+	err := r.db.QueryRowContext(ctx, query, ban.UserID, pq.StringArray(ban.Scopes), ban.Reason, ban.ExpiresAt, ban.CreatedBy).Scan(&id)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+		return uuid.UUID{}, domain.ErrUserNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "repository create ban error", err.Error())
+		return uuid.UUID{}, err
+	}
+	return id, nil
+}
+
+// ListByUser returns every ban of the user, most recent first.
+func (r *Repository) ListByUser(ctx context.Context, userID uuid.UUID) ([]domain.Ban, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE user_id = $1 ORDER BY created_at DESC`, banColumns, postgres.Bans)
+	return r.list(ctx, query, userID)
+}
+
+// ListActive returns the bans that are neither lifted nor expired.
+func (r *Repository) ListActive(ctx context.Context) ([]domain.Ban, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE lifted_at IS NULL AND (expires_at IS NULL OR expires_at > now())`, banColumns, postgres.Bans)
+	return r.list(ctx, query)
+}
+
+// ListExpired returns the bans that expired but have not been lifted yet.
+func (r *Repository) ListExpired(ctx context.Context) ([]domain.Ban, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE lifted_at IS NULL AND expires_at <= now() ORDER BY expires_at`, banColumns, postgres.Bans)
+	return r.list(ctx, query)
+}
+
+func (r *Repository) list(ctx context.Context, query string, args ...any) ([]domain.Ban, error) {
+	var rows []banRow
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		r.log.Error(ctx, "repository list bans error", err.Error())
+		return nil, err
+	}
+	bans := make([]domain.Ban, 0, len(rows))
+	for _, row := range rows {
+		bans = append(bans, row.toDomain())
+	}
+	return bans, nil
+}
+
+// Lift ends a ban at the given time. liftedBy is nil when the ban is lifted
+// because it expired. Lifting a ban twice fails with domain.ErrBanLifted.
+func (r *Repository) Lift(ctx context.Context, id uuid.UUID, liftedBy *uuid.UUID, at time.Time) (domain.Ban, error) {
+	var row banRow
+	query := fmt.Sprintf(`
+		UPDATE %s SET lifted_at = $3, lifted_by = $2
+		WHERE id = $1 AND lifted_at IS NULL
+		RETURNING %s`, postgres.Bans, banColumns)
+	err := r.db.GetContext(ctx, &row, query, id, liftedBy, at)
+	if errors.Is(err, sql.ErrNoRows) {
+		var exists bool
+		query := fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s WHERE id = $1)`, postgres.Bans)
+		if err := r.db.GetContext(ctx, &exists, query, id); err != nil {
+			return domain.Ban{}, err
+		}
+		if !exists {
+			return domain.Ban{}, domain.ErrBanNotFound
+		}
+		return domain.Ban{}, domain.ErrBanLifted
+	}
+	if err != nil {
+		r.log.Error(ctx, "repository lift ban error", err.Error())
+		return domain.Ban{}, err
+	}
+	return row.toDomain(), nil
+}
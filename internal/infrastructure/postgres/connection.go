@@ -1,15 +1,30 @@
 package postgres
 
 import (
+	"OnlineLeadership/internal/infrastructure/metrics"
 	"fmt"
+	"github.com/XSAM/otelsql"
 	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
 )
 
 const (
 	Users        = "users"
 	Games        = "games"
 	ScoreHistory = "score_history"
+	Quarantine   = "score_quarantine"
+	ScoreFlags   = "score_flags"
+	AuditLog     = "audit_log"
+	Bans         = "bans"
+	Snapshots    = "rank_snapshots"
+	// LeaderboardScores and LeaderboardVersions back leaderboard.store = postgres.
+	LeaderboardScores   = "leaderboard_scores"
+	LeaderboardVersions = "leaderboard_versions"
+	// SubmissionNonces remembers the submission nonces when Redis is not configured.
+	SubmissionNonces = "submission_nonces"
+	// SchemaMigrations is the bookkeeping table of golang-migrate.
+	SchemaMigrations = "schema_migrations"
 )
 
 func Connect(username, password, host, port, databaseName, sslMode string) (*sqlx.DB, error) {
@@ -23,12 +38,22 @@ func Connect(username, password, host, port, databaseName, sslMode string) (*sql
 		sslMode,
 	)
 
-	db, err := sqlx.Connect("postgres", dsn)
+	connector, err := pq.NewConnector(dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
+	// Calls through the pool are timed for the Prometheus metrics and traced.
+	db := sqlx.NewDb(otelsql.OpenDB(
+		metrics.InstrumentConnector(connector),
+		otelsql.WithAttributes(semconv.DBSystemNamePostgreSQL),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{
+			OmitConnResetSession: true,
+			OmitRows:             true,
+		}),
+	), "postgres")
 	err = db.Ping()
 	if err != nil {
+		db.Close()
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 	return db, nil
@@ -0,0 +1,123 @@
+package flag
+
+import (
+	"OnlineLeadership/internal/domain"
+	"OnlineLeadership/internal/infrastructure/logger"
+	"OnlineLeadership/internal/infrastructure/postgres"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// flagColumns selects a flag joined with the score history entry it reports.
+const flagColumns = `f.id, f.score_id, s.user_id, s.game_id, s.score, f.reporter_id, f.source, f.reason,
+	f.status, f.moderator_id, f.decided_at, f.created_at`
+
+type Repository struct {
+	db  *sqlx.DB
+	log *logger.SlogLogger
+}
+
+func NewFlagRepository(db *sqlx.DB, log *logger.SlogLogger) *Repository {
+	return &Repository{db: db, log: log}
+}
+
+func (r *Repository) Create(ctx context.Context, flag domain.ScoreFlag) (uuid.UUID, error) {
+	var id uuid.UUID
+	query := fmt.Sprintf(`INSERT INTO %s (score_id, reporter_id, source, reason) VALUES ($1, $2, $3, $4) RETURNING id`, postgres.ScoreFlags)
+	err := r.db.QueryRowContext(ctx, query, flag.ScoreID, flag.ReporterID, flag.Source, flag.Reason).Scan(&id)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case "23505":
+			return uuid.UUID{}, domain.ErrAlreadyFlagged
+		case "23503":
+			return uuid.UUID{}, domain.ErrScoreNotFound
+		}
+	}
+	if err != nil {
+		r.log.Error(ctx, "repository create flag error", err.Error())
+		return uuid.UUID{}, err
+	}
+	return id, nil
+}
+
+func (r *Repository) Get(ctx context.Context, id uuid.UUID) (domain.ScoreFlag, error) {
+	var flag domain.ScoreFlag
+	query := fmt.Sprintf(`SELECT %s FROM %s f JOIN %s s ON s.id = f.score_id WHERE f.id = $1`,
+		flagColumns, postgres.ScoreFlags, postgres.ScoreHistory)
+	err := r.db.GetContext(ctx, &flag, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ScoreFlag{}, domain.ErrFlagNotFound
+	}
+	return flag, err
+}
+
+// List returns flags with the given status, oldest first.
+func (r *Repository) List(ctx context.Context, status string, offset int, limit int) ([]domain.ScoreFlag, error) {
+	flags := []domain.ScoreFlag{}
+	query := fmt.Sprintf(`
+		SELECT %s FROM %s f JOIN %s s ON s.id = f.score_id
+		WHERE f.status = $1 ORDER BY f.created_at, f.id OFFSET $2 LIMIT $3`,
+		flagColumns, postgres.ScoreFlags, postgres.ScoreHistory)
+	if err := r.db.SelectContext(ctx, &flags, query, status, offset, limit); err != nil {
+		r.log.Error(ctx, "repository list flags error", err.Error())
+		return nil, err
+	}
+	return flags, nil
+}
+
+// Decide resolves the pending flag and every other pending flag on the same
+// score with the moderator's decision. A rejection also marks the score as
+// rejected; rejected reports whether this decision did so, in which case the
+// caller must remove the score from the leaderboards.
+func (r *Repository) Decide(ctx context.Context, id uuid.UUID, decision string, moderatorID uuid.UUID) (domain.ScoreRecord, bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return domain.ScoreRecord{}, false, err
+	}
+	defer tx.Rollback()
+
+	var scoreID uuid.UUID
+	var status string
+	query := fmt.Sprintf(`SELECT score_id, status FROM %s WHERE id = $1 FOR UPDATE`, postgres.ScoreFlags)
+	err = tx.QueryRowContext(ctx, query, id).Scan(&scoreID, &status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ScoreRecord{}, false, domain.ErrFlagNotFound
+	}
+	if err != nil {
+		return domain.ScoreRecord{}, false, err
+	}
+	if status != domain.FlagPending {
+		return domain.ScoreRecord{}, false, domain.ErrFlagResolved
+	}
+
+	query = fmt.Sprintf(`
+		UPDATE %s SET status = $2, moderator_id = $3, decided_at = now()
+		WHERE score_id = $1 AND status = 'pending'`, postgres.ScoreFlags)
+	if _, err := tx.ExecContext(ctx, query, scoreID, decision, moderatorID); err != nil {
+		r.log.Error(ctx, "repository decide flags error", err.Error())
+		return domain.ScoreRecord{}, false, err
+	}
+
+	var record domain.ScoreRecord
+	var rejected bool
+	query = fmt.Sprintf(`SELECT id, user_id, game_id, score, kind, status, created_at FROM %s WHERE id = $1 FOR UPDATE`, postgres.ScoreHistory)
+	if err := tx.GetContext(ctx, &record, query, scoreID); err != nil {
+		return domain.ScoreRecord{}, false, err
+	}
+	if decision == domain.FlagRejected && record.Status == domain.ScoreAccepted {
+		query = fmt.Sprintf(`UPDATE %s SET status = $2 WHERE id = $1`, postgres.ScoreHistory)
+		if _, err := tx.ExecContext(ctx, query, scoreID, domain.ScoreRejected); err != nil {
+			r.log.Error(ctx, "repository reject score error", err.Error())
+			return domain.ScoreRecord{}, false, err
+		}
+		record.Status = domain.ScoreRejected
+		rejected = true
+	}
+	return record, rejected, tx.Commit()
+}
@@ -0,0 +1,54 @@
+package health
+
+import (
+	"OnlineLeadership/internal/infrastructure/logger"
+	"OnlineLeadership/internal/infrastructure/postgres"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"github.com/jmoiron/sqlx"
+)
+
+// Repository probes the connections the service depends on. redis is nil when
+// no configured store uses Redis.
+type Repository struct {
+	db    *sqlx.DB
+	redis *redis.Client
+	log   *logger.SlogLogger
+}
+
+func NewHealthRepository(db *sqlx.DB, redis *redis.Client, log *logger.SlogLogger) *Repository {
+	return &Repository{db: db, redis: redis, log: log}
+}
+
+func (r *Repository) PingPostgres(ctx context.Context) error {
+	return r.db.PingContext(ctx)
+}
+
+func (r *Repository) PingRedis(ctx context.Context) error {
+	return r.redis.Ping(ctx).Err()
+}
+
+func (r *Repository) RedisConfigured() bool {
+	return r.redis != nil
+}
+
+// SchemaVersion returns the version recorded by golang-migrate and whether the
+// last migration failed half-way. A database never migrated reports version 0.
+func (r *Repository) SchemaVersion(ctx context.Context) (uint, bool, error) {
+	var row struct {
+		Version uint `db:"version"`
+		Dirty   bool `db:"dirty"`
+	}
+	query := fmt.Sprintf(`SELECT version, dirty FROM %s LIMIT 1`, postgres.SchemaMigrations)
+	err := r.db.GetContext(ctx, &row, query)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return row.Version, row.Dirty, nil
+}
@@ -0,0 +1,383 @@
+package repository
+
+import (
+	"OnlineLeadership/internal/domain"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryLeaderboard keeps the boards in process, in skip lists that answer
+// rank and offset queries in O(log n). It behaves like LeaderboardRepo, ties
+// and cursors included, but its boards are lost on restart and are not shared
+// between instances: it suits tests, single-binary demos and a local fallback.
+type MemoryLeaderboard struct {
+	mu sync.RWMutex
+	// boards holds the sorted sets under the keys LeaderboardRepo uses in Redis.
+	boards   map[string]*skipList
+	versions map[string]domain.BoardVersion
+}
+
+func NewMemoryLeaderboard() *MemoryLeaderboard {
+	return &MemoryLeaderboard{
+		boards:   make(map[string]*skipList),
+		versions: make(map[string]domain.BoardVersion),
+	}
+}
+
+// board returns the board under key, creating it when create is set. A
+// missing board is returned as an empty one that is not stored.
+func (m *MemoryLeaderboard) board(key string, create bool) *skipList {
+	b, ok := m.boards[key]
+	if !ok {
+		b = newSkipList()
+		if create {
+			m.boards[key] = b
+		}
+	}
+	return b
+}
+
+// drop deletes the board under key once its last member is gone, as Redis does.
+func (m *MemoryLeaderboard) drop(key string) {
+	if b, ok := m.boards[key]; ok && b.Len() == 0 {
+		delete(m.boards, key)
+	}
+}
+
+func (m *MemoryLeaderboard) bumpVersion(gameID string) {
+	v := m.versions[gameID]
+	v.Version++
+	v.UpdatedAt = time.UnixMilli(time.Now().UnixMilli()).UTC()
+	m.versions[gameID] = v
+}
+
+func (m *MemoryLeaderboard) IncrementGameScore(_ context.Context, gameID string, userID string, region string, score int) error {
+	if gameID == "" {
+		return fmt.Errorf("gameID must not be empty")
+	}
+	if userID == "" {
+		return fmt.Errorf("userID must not be empty")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := gameKey(gameID)
+	m.board(key, true).Incr(userID, float64(score))
+	if region != "" {
+		m.board(regionKey(key, region), true).Incr(userID, float64(score))
+	}
+	m.bumpVersion(gameID)
+	return nil
+}
+
+// SetBestGameScore records the score on the game board only if it beats the
+// member's current one and returns the increase.
+func (m *MemoryLeaderboard) SetBestGameScore(_ context.Context, gameID string, userID string, region string, score int) (int64, error) {
+	if gameID == "" {
+		return 0, fmt.Errorf("gameID must not be empty")
+	}
+	if userID == "" {
+		return 0, fmt.Errorf("userID must not be empty")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := gameKey(gameID)
+	old, ok := m.board(key, false).Score(userID)
+	if ok && old >= float64(score) {
+		return 0, nil
+	}
+	m.board(key, true).Set(userID, float64(score))
+	if region != "" {
+		m.board(regionKey(key, region), true).Set(userID, float64(score))
+	}
+	m.bumpVersion(gameID)
+	return int64(score) - int64(old), nil
+}
+
+// ReplaceGameScore overwrites the member's game score, or removes the member
+// from the game board when keep is false, and returns the change.
+func (m *MemoryLeaderboard) ReplaceGameScore(_ context.Context, gameID string, userID string, region string, score int64, keep bool) (int64, error) {
+	if gameID == "" {
+		return 0, fmt.Errorf("gameID must not be empty")
+	}
+	if userID == "" {
+		return 0, fmt.Errorf("userID must not be empty")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := gameKey(gameID)
+	keys := []string{key}
+	if region != "" {
+		keys = append(keys, regionKey(key, region))
+	}
+	old, _ := m.board(key, false).Score(userID)
+	var change int64
+	if keep {
+		change = score - int64(old)
+		for _, k := range keys {
+			m.board(k, true).Set(userID, float64(score))
+		}
+	} else {
+		change = -int64(old)
+		for _, k := range keys {
+			m.board(k, false).Remove(userID)
+			m.drop(k)
+		}
+	}
+	m.bumpVersion(gameID)
+	return change, nil
+}
+
+// RemoveMember takes the user off the given game boards, subtracting their game
+// scores from the global boards. With global set the user is also removed from
+// the global boards.
+func (m *MemoryLeaderboard) RemoveMember(_ context.Context, gameIDs []uuid.UUID, userID string, region string, global bool) error {
+	if userID == "" {
+		return fmt.Errorf("userID must not be empty")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, gameID := range gameIDs {
+		key := gameKey(gameID.String())
+		score, ok := m.board(key, false).Score(userID)
+		if !ok {
+			continue
+		}
+		m.board(key, false).Remove(userID)
+		m.drop(key)
+		m.board(globalKey, true).Incr(userID, -score)
+		if region != "" {
+			m.board(regionKey(key, region), false).Remove(userID)
+			m.drop(regionKey(key, region))
+			m.board(regionKey(globalKey, region), true).Incr(userID, -score)
+		}
+		m.bumpVersion(gameID.String())
+	}
+	if !global {
+		return nil
+	}
+	m.board(globalKey, false).Remove(userID)
+	m.drop(globalKey)
+	if region != "" {
+		m.board(regionKey(globalKey, region), false).Remove(userID)
+		m.drop(regionKey(globalKey, region))
+	}
+	return nil
+}
+
+// SetGlobalScore overwrites the user's score on the global boards.
+func (m *MemoryLeaderboard) SetGlobalScore(_ context.Context, userID string, region string, score int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.board(globalKey, true).Set(userID, float64(score))
+	if region != "" {
+		m.board(regionKey(globalKey, region), true).Set(userID, float64(score))
+	}
+	return nil
+}
+
+func (m *MemoryLeaderboard) IncrementGlobalScore(_ context.Context, userID string, region string, score int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.board(globalKey, true).Incr(userID, float64(score))
+	if region != "" {
+		m.board(regionKey(globalKey, region), true).Incr(userID, float64(score))
+	}
+	return nil
+}
+
+func (m *MemoryLeaderboard) GetGlobal(_ context.Context, region string, page domain.PageRequest) (domain.LeaderboardPage, error) {
+	return m.getPage(regionKey(globalKey, region), page)
+}
+
+func (m *MemoryLeaderboard) GetMyRank(_ context.Context, userID uuid.UUID, region string) (domain.RankStats, error) {
+	return m.rankStats(regionKey(globalKey, region), userID.String()), nil
+}
+
+func (m *MemoryLeaderboard) GetGameRank(_ context.Context, gameID uuid.UUID, userID uuid.UUID, region string) (domain.RankStats, error) {
+	if gameID == uuid.Nil {
+		return domain.RankStats{}, fmt.Errorf("gameID must not be empty")
+	}
+	return m.rankStats(regionKey(gameKey(gameID.String()), region), userID.String()), nil
+}
+
+func (m *MemoryLeaderboard) rankStats(key string, member string) domain.RankStats {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	b := m.board(key, false)
+	stats := domain.RankStats{Rank: -1, Total: int64(b.Len())}
+	if rank := b.Rank(member); rank > 0 {
+		stats.Rank = int64(rank)
+	}
+	return stats
+}
+
+// GetDistribution builds a histogram of the game board's scores. Bucket counts
+// are range counts on the skip list, so there is nothing to cache.
+func (m *MemoryLeaderboard) GetDistribution(_ context.Context, gameID uuid.UUID, region string, buckets int) (domain.ScoreDistribution, error) {
+	if gameID == uuid.Nil {
+		return domain.ScoreDistribution{}, fmt.Errorf("gameID must not be empty")
+	}
+	if buckets <= 0 {
+		return domain.ScoreDistribution{}, fmt.Errorf("buckets must be positive")
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	b := m.board(regionKey(gameKey(gameID.String()), region), false)
+	dist := domain.ScoreDistribution{Total: int64(b.Len()), Buckets: []domain.ScoreBucket{}}
+	if dist.Total == 0 {
+		return dist, nil
+	}
+	dist.Max = int64(b.Range(0, 1)[0].Score)
+	dist.Min = int64(b.Range(b.Len()-1, 1)[0].Score)
+
+	width := (dist.Max - dist.Min + int64(buckets)) / int64(buckets)
+	for from := dist.Min; from <= dist.Max; from += width {
+		to := min(from+width-1, dist.Max)
+		dist.Buckets = append(dist.Buckets, domain.ScoreBucket{
+			From:  from,
+			To:    to,
+			Count: int64(b.Count(float64(from), float64(to))),
+		})
+	}
+	return dist, nil
+}
+
+func (m *MemoryLeaderboard) GetLeaderboard(_ context.Context, gameID uuid.UUID, region string, page domain.PageRequest) (domain.LeaderboardPage, error) {
+	if gameID == uuid.Nil {
+		return domain.LeaderboardPage{}, fmt.Errorf("gameID must not be empty")
+	}
+	return m.getPage(regionKey(gameKey(gameID.String()), region), page)
+}
+
+// getPage returns the page at the offset or after the cursor. Both are a
+// position lookup followed by a walk along the bottom level of the skip list.
+func (m *MemoryLeaderboard) getPage(key string, page domain.PageRequest) (domain.LeaderboardPage, error) {
+	if page.Limit <= 0 {
+		return domain.LeaderboardPage{}, fmt.Errorf("limit must be positive")
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	b := m.board(key, false)
+	skipped := page.Offset
+	if page.Cursor != nil {
+		skipped = b.Through(page.Cursor.Score, page.Cursor.Member)
+	}
+	rows := b.Range(skipped, page.Limit)
+
+	result := domain.LeaderboardPage{Users: make([]domain.LeaderboardUser, 0, len(rows)), Total: int64(b.Len())}
+	for i, row := range rows {
+		userID, err := uuid.Parse(row.Member)
+		if err != nil {
+			continue
+		}
+		result.Users = append(result.Users, domain.LeaderboardUser{
+			UserID: userID,
+			Score:  int64(row.Score),
+			Rank:   int64(skipped + i + 1),
+		})
+	}
+	if len(rows) == page.Limit && int64(skipped+len(rows)) < result.Total {
+		last := rows[len(rows)-1]
+		result.NextCursor = domain.Cursor{Score: last.Score, Member: last.Member}.Encode()
+	}
+	return result, nil
+}
+
+// BoardSizes returns the number of players on the global board and on each of
+// the given game boards, keyed by "global" and the game id.
+func (m *MemoryLeaderboard) BoardSizes(_ context.Context, gameIDs []uuid.UUID) (map[string]int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	sizes := make(map[string]int64, len(gameIDs)+1)
+	sizes["global"] = int64(m.board(globalKey, false).Len())
+	for _, id := range gameIDs {
+		sizes[id.String()] = int64(m.board(gameKey(id.String()), false).Len())
+	}
+	return sizes, nil
+}
+
+// GetBoardVersion returns the version of a game board; a board that was never
+// written to has version 0.
+func (m *MemoryLeaderboard) GetBoardVersion(_ context.Context, gameID uuid.UUID) (domain.BoardVersion, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.versions[gameID.String()], nil
+}
+
+// PurgeGame subtracts the game's contribution from the global and regional
+// boards, keeping every player on them, and deletes the game's boards and
+// version.
+func (m *MemoryLeaderboard) PurgeGame(_ context.Context, gameID uuid.UUID) error {
+	if gameID == uuid.Nil {
+		return fmt.Errorf("gameID must not be empty")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := gameKey(gameID.String())
+	regionPrefix := key + ":region:"
+	for k, b := range m.boards {
+		target := globalKey
+		if k != key {
+			region, ok := strings.CutPrefix(k, regionPrefix)
+			if !ok {
+				continue
+			}
+			target = regionKey(globalKey, region)
+		}
+		global := m.board(target, true)
+		b.Each(func(member string, score float64) {
+			global.Incr(member, -score)
+		})
+		m.drop(target)
+		delete(m.boards, k)
+	}
+	delete(m.versions, gameID.String())
+	return nil
+}
+
+// MoveRegion moves the user's scores from the boards of one region to another,
+// copying the user's score on the global board and on each of the given game
+// boards into the new region.
+func (m *MemoryLeaderboard) MoveRegion(_ context.Context, userID uuid.UUID, gameIDs []uuid.UUID, from string, to string) error {
+	if from == to {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	member := userID.String()
+	boards := make([]string, 0, len(gameIDs)+1)
+	boards = append(boards, globalKey)
+	for _, gameID := range gameIDs {
+		boards = append(boards, gameKey(gameID.String()))
+	}
+	for _, board := range boards {
+		if from != "" {
+			m.board(regionKey(board, from), false).Remove(member)
+			m.drop(regionKey(board, from))
+		}
+		score, ok := m.board(board, false).Score(member)
+		if ok && to != "" {
+			m.board(regionKey(board, to), true).Set(member, score)
+		}
+	}
+	for _, gameID := range gameIDs {
+		m.bumpVersion(gameID.String())
+	}
+	return nil
+}
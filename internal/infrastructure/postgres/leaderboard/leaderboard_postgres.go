@@ -0,0 +1,582 @@
+package repository
+
+import (
+	"OnlineLeadership/internal/domain"
+	"OnlineLeadership/internal/infrastructure/logger"
+	"OnlineLeadership/internal/infrastructure/postgres"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"time"
+)
+
+// globalBoard is the board column of the global board's rows.
+const globalBoard = "global"
+
+// PostgresLeaderboard keeps the boards in the leaderboard_scores table, one row
+// per player and board, maintained on every submit. Pages and rank lookups walk
+// the (board, region, score DESC, member DESC) index, so no Redis is needed
+// for the leaderboards.
+type PostgresLeaderboard struct {
+	db  *sqlx.DB
+	log *logger.SlogLogger
+}
+
+func NewPostgresLeaderboard(db *sqlx.DB, log *logger.SlogLogger) *PostgresLeaderboard {
+	return &PostgresLeaderboard{db: db, log: log}
+}
+
+// boardRegions returns the regions a write with the given region touches: the
+// overall board and, for a non-empty region, its regional variant.
+func boardRegions(region string) pq.StringArray {
+	if region == "" {
+		return pq.StringArray{""}
+	}
+	return pq.StringArray{"", region}
+}
+
+// inTx runs fn in a transaction, committing when it returns no error.
+func (r *PostgresLeaderboard) inTx(ctx context.Context, fn func(tx *sqlx.Tx) error) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// incrementScore adds delta to the member's score on the board in each region,
+// adding the member at delta where it has no row.
+func incrementScore(ctx context.Context, tx sqlx.ExecerContext, board string, regions pq.StringArray, member string, delta int64) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s AS s (board, region, member, score)
+		SELECT $1::text, r, $3::text, $4::bigint FROM unnest($2::text[]) AS r
+		ON CONFLICT (board, region, member) DO UPDATE SET score = s.score + EXCLUDED.score
+	`, postgres.LeaderboardScores)
+	_, err := tx.ExecContext(ctx, query, board, regions, member, delta)
+	return err
+}
+
+// setScore overwrites the member's score on the board in each region.
+func setScore(ctx context.Context, tx sqlx.ExecerContext, board string, regions pq.StringArray, member string, score int64) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (board, region, member, score)
+		SELECT $1::text, r, $3::text, $4::bigint FROM unnest($2::text[]) AS r
+		ON CONFLICT (board, region, member) DO UPDATE SET score = EXCLUDED.score
+	`, postgres.LeaderboardScores)
+	_, err := tx.ExecContext(ctx, query, board, regions, member, score)
+	return err
+}
+
+// removeScore deletes the member's rows on the board in each region and
+// returns its score on the overall board, if it had one.
+func removeScore(ctx context.Context, tx *sqlx.Tx, board string, regions pq.StringArray, member string) (int64, bool, error) {
+	query := fmt.Sprintf(`
+		DELETE FROM %s WHERE board = $1 AND region = ANY($2) AND member = $3
+		RETURNING region, score
+	`, postgres.LeaderboardScores)
+	rows, err := tx.QueryContext(ctx, query, board, regions, member)
+	if err != nil {
+		return 0, false, err
+	}
+	defer rows.Close()
+
+	var score int64
+	var found bool
+	for rows.Next() {
+		var region string
+		var s int64
+		if err := rows.Scan(&region, &s); err != nil {
+			return 0, false, err
+		}
+		if region == "" {
+			score, found = s, true
+		}
+	}
+	return score, found, rows.Err()
+}
+
+// claimScore locks the member's row on the overall game board and returns its
+// score. A member without a row is inserted at score instead, and existed is
+// false; if another transaction inserts it first, the row is locked after all.
+func claimScore(ctx context.Context, tx *sqlx.Tx, gameID string, member string, score int64) (old int64, existed bool, err error) {
+	lock := fmt.Sprintf(`
+		SELECT score FROM %s WHERE board = $1 AND region = '' AND member = $2 FOR UPDATE
+	`, postgres.LeaderboardScores)
+	insert := fmt.Sprintf(`
+		INSERT INTO %s (board, region, member, score) VALUES ($1, '', $2, $3)
+		ON CONFLICT DO NOTHING
+	`, postgres.LeaderboardScores)
+	for {
+		err := tx.QueryRowContext(ctx, lock, gameID, member).Scan(&old)
+		if err == nil {
+			return old, true, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return 0, false, err
+		}
+		res, err := tx.ExecContext(ctx, insert, gameID, member, score)
+		if err != nil {
+			return 0, false, err
+		}
+		if n, err := res.RowsAffected(); err != nil || n == 1 {
+			return 0, false, err
+		}
+	}
+}
+
+// bumpVersions increments the version of each game board and sets its update time.
+func bumpVersions(ctx context.Context, tx sqlx.ExecerContext, gameIDs ...string) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s AS v (game_id, version, updated_at)
+		SELECT DISTINCT g, 1, $2::timestamp FROM unnest($1::text[]) AS g
+		ON CONFLICT (game_id) DO UPDATE SET version = v.version + 1, updated_at = EXCLUDED.updated_at
+	`, postgres.LeaderboardVersions)
+	_, err := tx.ExecContext(ctx, query, pq.StringArray(gameIDs), time.UnixMilli(time.Now().UnixMilli()).UTC())
+	return err
+}
+
+func (r *PostgresLeaderboard) IncrementGameScore(ctx context.Context, gameID string, userID string, region string, score int) error {
+	if gameID == "" {
+		return fmt.Errorf("gameID must not be empty")
+	}
+	if userID == "" {
+		return fmt.Errorf("userID must not be empty")
+	}
+	err := r.inTx(ctx, func(tx *sqlx.Tx) error {
+		if err := incrementScore(ctx, tx, gameID, boardRegions(region), userID, int64(score)); err != nil {
+			return err
+		}
+		return bumpVersions(ctx, tx, gameID)
+	})
+	if err != nil {
+		r.log.Error(ctx, "repository increment game score error", err.Error())
+	}
+	return err
+}
+
+// SetBestGameScore records the score on the game board only if it beats the
+// member's current one and returns the increase, which the caller adds to the
+// global boards.
+func (r *PostgresLeaderboard) SetBestGameScore(ctx context.Context, gameID string, userID string, region string, score int) (int64, error) {
+	if gameID == "" {
+		return 0, fmt.Errorf("gameID must not be empty")
+	}
+	if userID == "" {
+		return 0, fmt.Errorf("userID must not be empty")
+	}
+	var increase int64
+	err := r.inTx(ctx, func(tx *sqlx.Tx) error {
+		old, existed, err := claimScore(ctx, tx, gameID, userID, int64(score))
+		if err != nil {
+			return err
+		}
+		if existed && old >= int64(score) {
+			return nil
+		}
+		if err := setScore(ctx, tx, gameID, boardRegions(region), userID, int64(score)); err != nil {
+			return err
+		}
+		increase = int64(score) - old
+		return bumpVersions(ctx, tx, gameID)
+	})
+	if err != nil {
+		r.log.Error(ctx, "repository set best game score error", err.Error())
+		return 0, err
+	}
+	return increase, nil
+}
+
+// ReplaceGameScore overwrites the member's game score, or removes the member
+// from the game board when keep is false, and returns the change, which the
+// caller applies to the global boards.
+func (r *PostgresLeaderboard) ReplaceGameScore(ctx context.Context, gameID string, userID string, region string, score int64, keep bool) (int64, error) {
+	if gameID == "" {
+		return 0, fmt.Errorf("gameID must not be empty")
+	}
+	if userID == "" {
+		return 0, fmt.Errorf("userID must not be empty")
+	}
+	var change int64
+	err := r.inTx(ctx, func(tx *sqlx.Tx) error {
+		if keep {
+			old, _, err := claimScore(ctx, tx, gameID, userID, score)
+			if err != nil {
+				return err
+			}
+			if err := setScore(ctx, tx, gameID, boardRegions(region), userID, score); err != nil {
+				return err
+			}
+			change = score - old
+		} else {
+			old, _, err := removeScore(ctx, tx, gameID, boardRegions(region), userID)
+			if err != nil {
+				return err
+			}
+			change = -old
+		}
+		return bumpVersions(ctx, tx, gameID)
+	})
+	if err != nil {
+		r.log.Error(ctx, "repository replace game score error", err.Error())
+		return 0, err
+	}
+	return change, nil
+}
+
+// RemoveMember takes the user off the given game boards, subtracting their game
+// scores from the global boards. With global set the user is also removed from
+// the global boards. Each step is idempotent, so a failed removal can be retried.
+func (r *PostgresLeaderboard) RemoveMember(ctx context.Context, gameIDs []uuid.UUID, userID string, region string, global bool) error {
+	if userID == "" {
+		return fmt.Errorf("userID must not be empty")
+	}
+	regions := boardRegions(region)
+	err := r.inTx(ctx, func(tx *sqlx.Tx) error {
+		for _, gameID := range gameIDs {
+			score, found, err := removeScore(ctx, tx, gameID.String(), regions, userID)
+			if err != nil {
+				return err
+			}
+			if !found {
+				continue
+			}
+			if err := incrementScore(ctx, tx, globalBoard, regions, userID, -score); err != nil {
+				return err
+			}
+			if err := bumpVersions(ctx, tx, gameID.String()); err != nil {
+				return err
+			}
+		}
+		if !global {
+			return nil
+		}
+		_, _, err := removeScore(ctx, tx, globalBoard, regions, userID)
+		return err
+	})
+	if err != nil {
+		r.log.Error(ctx, "repository remove member error", err.Error())
+	}
+	return err
+}
+
+// SetGlobalScore overwrites the user's score on the global boards.
+func (r *PostgresLeaderboard) SetGlobalScore(ctx context.Context, userID string, region string, score int64) error {
+	return setScore(ctx, r.db, globalBoard, boardRegions(region), userID, score)
+}
+
+func (r *PostgresLeaderboard) IncrementGlobalScore(ctx context.Context, userID string, region string, score int) error {
+	return incrementScore(ctx, r.db, globalBoard, boardRegions(region), userID, int64(score))
+}
+
+func (r *PostgresLeaderboard) GetGlobal(ctx context.Context, region string, page domain.PageRequest) (domain.LeaderboardPage, error) {
+	return r.getPage(ctx, globalBoard, region, page)
+}
+
+func (r *PostgresLeaderboard) GetMyRank(ctx context.Context, userID uuid.UUID, region string) (domain.RankStats, error) {
+	return r.rankStats(ctx, globalBoard, region, userID.String())
+}
+
+func (r *PostgresLeaderboard) GetGameRank(ctx context.Context, gameID uuid.UUID, userID uuid.UUID, region string) (domain.RankStats, error) {
+	if gameID == uuid.Nil {
+		return domain.RankStats{}, fmt.Errorf("gameID must not be empty")
+	}
+	return r.rankStats(ctx, gameID.String(), region, userID.String())
+}
+
+// rankStats counts the rows up to the member's in one statement. RANK() over
+// the board would give tied players the same rank and read the whole board;
+// the count is an index-only range scan that yields the ordinal rank. The
+// total is counted too, as board sizes are not stored, so a lookup costs a
+// scan of the board's index entries.
+func (r *PostgresLeaderboard) rankStats(ctx context.Context, board string, region string, member string) (domain.RankStats, error) {
+	query := fmt.Sprintf(`
+		SELECT
+			(SELECT COUNT(*) FROM %[1]s WHERE board = $1 AND region = $2),
+			(SELECT COUNT(*) FROM %[1]s s, %[1]s m
+			 WHERE m.board = $1 AND m.region = $2 AND m.member = $3
+			   AND s.board = $1 AND s.region = $2 AND (s.score, s.member) >= (m.score, m.member))
+	`, postgres.LeaderboardScores)
+	var total, rank int64
+	if err := r.db.QueryRowContext(ctx, query, board, region, member).Scan(&total, &rank); err != nil {
+		r.log.Error(ctx, "repository rank stats error", err.Error())
+		return domain.RankStats{}, err
+	}
+	stats := domain.RankStats{Rank: -1, Total: total}
+	if rank > 0 {
+		stats.Rank = rank
+	}
+	return stats, nil
+}
+
+// GetDistribution builds a histogram of the game board's scores with a single
+// GROUP BY over bucket numbers.
+func (r *PostgresLeaderboard) GetDistribution(ctx context.Context, gameID uuid.UUID, region string, buckets int) (domain.ScoreDistribution, error) {
+	if gameID == uuid.Nil {
+		return domain.ScoreDistribution{}, fmt.Errorf("gameID must not be empty")
+	}
+	if buckets <= 0 {
+		return domain.ScoreDistribution{}, fmt.Errorf("buckets must be positive")
+	}
+
+	dist := domain.ScoreDistribution{Buckets: []domain.ScoreBucket{}}
+	query := fmt.Sprintf(`
+		SELECT COUNT(*), COALESCE(MIN(score), 0), COALESCE(MAX(score), 0)
+		FROM %s WHERE board = $1 AND region = $2
+	`, postgres.LeaderboardScores)
+	err := r.db.QueryRowContext(ctx, query, gameID.String(), region).Scan(&dist.Total, &dist.Min, &dist.Max)
+	if err != nil {
+		r.log.Error(ctx, "repository score range error", err.Error())
+		return domain.ScoreDistribution{}, err
+	}
+	if dist.Total == 0 {
+		return dist, nil
+	}
+
+	width := (dist.Max - dist.Min + int64(buckets)) / int64(buckets)
+	for from := dist.Min; from <= dist.Max; from += width {
+		dist.Buckets = append(dist.Buckets, domain.ScoreBucket{From: from, To: min(from+width-1, dist.Max)})
+	}
+	query = fmt.Sprintf(`
+		SELECT (score - $3) / $4 AS bucket, COUNT(*)
+		FROM %s WHERE board = $1 AND region = $2
+		GROUP BY bucket
+	`, postgres.LeaderboardScores)
+	rows, err := r.db.QueryContext(ctx, query, gameID.String(), region, dist.Min, width)
+	if err != nil {
+		r.log.Error(ctx, "repository score distribution error", err.Error())
+		return domain.ScoreDistribution{}, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var bucket, count int64
+		if err := rows.Scan(&bucket, &count); err != nil {
+			return domain.ScoreDistribution{}, err
+		}
+		if bucket >= 0 && bucket < int64(len(dist.Buckets)) {
+			dist.Buckets[bucket].Count = count
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return domain.ScoreDistribution{}, err
+	}
+	return dist, nil
+}
+
+func (r *PostgresLeaderboard) GetLeaderboard(ctx context.Context, gameID uuid.UUID, region string, page domain.PageRequest) (domain.LeaderboardPage, error) {
+	if gameID == uuid.Nil {
+		return domain.LeaderboardPage{}, fmt.Errorf("gameID must not be empty")
+	}
+	return r.getPage(ctx, gameID.String(), region, page)
+}
+
+// getPage returns the page at the offset or after the cursor. Both read the
+// rank index from their starting row on; a cursor page skips straight to the
+// row after (score, member), and ROW_NUMBER() numbers the rows from there.
+// Total is a COUNT(*) of the board, which grows with the number of players.
+func (r *PostgresLeaderboard) getPage(ctx context.Context, board string, region string, page domain.PageRequest) (domain.LeaderboardPage, error) {
+	if page.Limit <= 0 {
+		return domain.LeaderboardPage{}, fmt.Errorf("limit must be positive")
+	}
+
+	var total, skipped int64
+	// Rows ahead of the page: the offset, or everything up to and including the cursor.
+	filter, offset := "", "OFFSET $4"
+	var cursorArgs []any
+	if page.Cursor != nil {
+		score, member := int64(page.Cursor.Score), page.Cursor.Member
+		query := fmt.Sprintf(`
+			SELECT
+				(SELECT COUNT(*) FROM %[1]s WHERE board = $1 AND region = $2),
+				(SELECT COUNT(*) FROM %[1]s WHERE board = $1 AND region = $2 AND (score, member) >= ($3, $4))
+		`, postgres.LeaderboardScores)
+		if err := r.db.QueryRowContext(ctx, query, board, region, score, member).Scan(&total, &skipped); err != nil {
+			r.log.Error(ctx, "repository cursor position error", err.Error())
+			return domain.LeaderboardPage{}, err
+		}
+		filter, offset = "AND (score, member) < ($5, $6)", ""
+		cursorArgs = []any{score, member}
+	} else {
+		query := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE board = $1 AND region = $2`, postgres.LeaderboardScores)
+		if err := r.db.QueryRowContext(ctx, query, board, region).Scan(&total); err != nil {
+			r.log.Error(ctx, "repository board size error", err.Error())
+			return domain.LeaderboardPage{}, err
+		}
+		skipped = int64(max(page.Offset, 0))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT member, score, $4::bigint + ROW_NUMBER() OVER (ORDER BY score DESC, member DESC) AS rank
+		FROM (
+			SELECT member, score FROM %s
+			WHERE board = $1 AND region = $2 %s
+			ORDER BY score DESC, member DESC
+			LIMIT $3 %s
+		) page
+		ORDER BY score DESC, member DESC
+	`, postgres.LeaderboardScores, filter, offset)
+	args := append([]any{board, region, page.Limit, skipped}, cursorArgs...)
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.log.Error(ctx, "repository leaderboard page error", err.Error())
+		return domain.LeaderboardPage{}, err
+	}
+	defer rows.Close()
+
+	result := domain.LeaderboardPage{Users: []domain.LeaderboardUser{}, Total: total}
+	var count int
+	var last domain.Cursor
+	for rows.Next() {
+		var member string
+		var score, rank int64
+		if err := rows.Scan(&member, &score, &rank); err != nil {
+			return domain.LeaderboardPage{}, err
+		}
+		count++
+		last = domain.Cursor{Score: float64(score), Member: member}
+		userID, err := uuid.Parse(member)
+		if err != nil {
+			continue
+		}
+		result.Users = append(result.Users, domain.LeaderboardUser{UserID: userID, Score: score, Rank: rank})
+	}
+	if err := rows.Err(); err != nil {
+		return domain.LeaderboardPage{}, err
+	}
+	if count == page.Limit && skipped+int64(count) < total {
+		result.NextCursor = last.Encode()
+	}
+	return result, nil
+}
+
+// BoardSizes returns the number of players on the global board and on each of
+// the given game boards, keyed by "global" and the game id.
+func (r *PostgresLeaderboard) BoardSizes(ctx context.Context, gameIDs []uuid.UUID) (map[string]int64, error) {
+	boards := make(pq.StringArray, 0, len(gameIDs)+1)
+	boards = append(boards, globalBoard)
+	for _, id := range gameIDs {
+		boards = append(boards, id.String())
+	}
+	query := fmt.Sprintf(`
+		SELECT board, COUNT(*) FROM %s
+		WHERE board = ANY($1) AND region = ''
+		GROUP BY board
+	`, postgres.LeaderboardScores)
+	rows, err := r.db.QueryContext(ctx, query, boards)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sizes := make(map[string]int64, len(boards))
+	for _, board := range boards {
+		sizes[board] = 0
+	}
+	for rows.Next() {
+		var board string
+		var size int64
+		if err := rows.Scan(&board, &size); err != nil {
+			return nil, err
+		}
+		sizes[board] = size
+	}
+	return sizes, rows.Err()
+}
+
+// GetBoardVersion returns the version of a game board; a board that was never
+// written to has version 0.
+func (r *PostgresLeaderboard) GetBoardVersion(ctx context.Context, gameID uuid.UUID) (domain.BoardVersion, error) {
+	query := fmt.Sprintf(`SELECT version, updated_at FROM %s WHERE game_id = $1`, postgres.LeaderboardVersions)
+	var version domain.BoardVersion
+	err := r.db.QueryRowContext(ctx, query, gameID.String()).Scan(&version.Version, &version.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.BoardVersion{}, nil
+	}
+	if err != nil {
+		return domain.BoardVersion{}, err
+	}
+	version.UpdatedAt = version.UpdatedAt.UTC()
+	return version, nil
+}
+
+// PurgeGame subtracts the game's contribution from the global and regional
+// boards, keeping every player on them, and deletes the game's rows and
+// version.
+func (r *PostgresLeaderboard) PurgeGame(ctx context.Context, gameID uuid.UUID) error {
+	if gameID == uuid.Nil {
+		return fmt.Errorf("gameID must not be empty")
+	}
+	err := r.inTx(ctx, func(tx *sqlx.Tx) error {
+		query := fmt.Sprintf(`
+			INSERT INTO %[1]s AS s (board, region, member, score)
+			SELECT $2::text, region, member, -score FROM %[1]s WHERE board = $1
+			ON CONFLICT (board, region, member) DO UPDATE SET score = s.score + EXCLUDED.score
+		`, postgres.LeaderboardScores)
+		if _, err := tx.ExecContext(ctx, query, gameID.String(), globalBoard); err != nil {
+			return err
+		}
+		query = fmt.Sprintf(`DELETE FROM %s WHERE board = $1`, postgres.LeaderboardScores)
+		if _, err := tx.ExecContext(ctx, query, gameID.String()); err != nil {
+			return err
+		}
+		query = fmt.Sprintf(`DELETE FROM %s WHERE game_id = $1`, postgres.LeaderboardVersions)
+		_, err := tx.ExecContext(ctx, query, gameID.String())
+		return err
+	})
+	if err != nil {
+		r.log.Error(ctx, "repository purge game error", err.Error())
+	}
+	return err
+}
+
+// MoveRegion moves the user's scores from the boards of one region to another.
+// The overall boards are the source of truth: the user's score on the global
+// board and on each of the given game boards is copied into the new region.
+func (r *PostgresLeaderboard) MoveRegion(ctx context.Context, userID uuid.UUID, gameIDs []uuid.UUID, from string, to string) error {
+	if from == to {
+		return nil
+	}
+	member := userID.String()
+	boards := make(pq.StringArray, 0, len(gameIDs)+1)
+	boards = append(boards, globalBoard)
+	games := make([]string, 0, len(gameIDs))
+	for _, gameID := range gameIDs {
+		boards = append(boards, gameID.String())
+		games = append(games, gameID.String())
+	}
+
+	err := r.inTx(ctx, func(tx *sqlx.Tx) error {
+		if from != "" {
+			query := fmt.Sprintf(`DELETE FROM %s WHERE board = ANY($1) AND region = $2 AND member = $3`, postgres.LeaderboardScores)
+			if _, err := tx.ExecContext(ctx, query, boards, from, member); err != nil {
+				return err
+			}
+		}
+		if to != "" {
+			query := fmt.Sprintf(`
+				INSERT INTO %[1]s (board, region, member, score)
+				SELECT board, $2::text, member, score FROM %[1]s
+				WHERE board = ANY($1) AND region = '' AND member = $3
+				ON CONFLICT (board, region, member) DO UPDATE SET score = EXCLUDED.score
+			`, postgres.LeaderboardScores)
+			if _, err := tx.ExecContext(ctx, query, boards, to, member); err != nil {
+				return err
+			}
+		}
+		if len(games) == 0 {
+			return nil
+		}
+		return bumpVersions(ctx, tx, games...)
+	})
+	if err != nil {
+		r.log.Error(ctx, "repository move region error", err.Error())
+	}
+	return err
+}
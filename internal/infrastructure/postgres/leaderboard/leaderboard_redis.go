@@ -4,13 +4,221 @@ import (
 	"OnlineLeadership/internal/domain"
 	"OnlineLeadership/internal/infrastructure/logger"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"strconv"
+	"time"
 )
 
+const (
+	globalKey = "leaderboard:global"
+
+	// distributionTTL bounds how stale a cached score histogram may get.
+	distributionTTL = 30 * time.Second
+)
+
+func gameKey(gameID string) string {
+	return fmt.Sprintf("leaderboard:game:%s", gameID)
+}
+
+// versionKey holds the version counter and last update time of a game board.
+func versionKey(gameID string) string {
+	return fmt.Sprintf("leaderboard:game:%s:version", gameID)
+}
+
+// bumpVersion queues the version increment of a game board on the pipeline.
+func bumpVersion(ctx context.Context, pipe redis.Pipeliner, gameID string) {
+	key := versionKey(gameID)
+	pipe.HIncrBy(ctx, key, "version", 1)
+	pipe.HSet(ctx, key, "updated_at", time.Now().UnixMilli())
+}
+
+// regionsKey holds the regions that have a regional variant of a game board,
+// so that the variants can be found without scanning the keyspace.
+func regionsKey(gameID string) string {
+	return fmt.Sprintf("leaderboard:game:%s:regions", gameID)
+}
+
+// regionKey returns the regional variant of a board key, or the board itself when region is empty.
+func regionKey(board string, region string) string {
+	if region == "" {
+		return board
+	}
+	return fmt.Sprintf("%s:region:%s", board, region)
+}
+
+// setBestScript keeps the best score of a member on a game board and its
+// regional variant, bumps the board version on change and returns how much the
+// member's game score grew.
+//
+// KEYS[1] game board, KEYS[2] version hash, KEYS[3] regional board and
+// KEYS[4] regions set (both optional)
+// ARGV[1] score, ARGV[2] member, ARGV[3] current time in Unix milliseconds, ARGV[4] region
+var setBestScript = redis.NewScript(`
+local old = redis.call('ZSCORE', KEYS[1], ARGV[2])
+local new = tonumber(ARGV[1])
+if old and tonumber(old) >= new then
+	return 0
+end
+redis.call('ZADD', KEYS[1], new, ARGV[2])
+if KEYS[3] then
+	redis.call('ZADD', KEYS[3], new, ARGV[2])
+	redis.call('SADD', KEYS[4], ARGV[4])
+end
+redis.call('HINCRBY', KEYS[2], 'version', 1)
+redis.call('HSET', KEYS[2], 'updated_at', ARGV[3])
+return new - (tonumber(old) or 0)
+`)
+
+// replaceScript sets or removes a member's score on a game board and its
+// regional variant, bumps the board version and returns how much the member's
+// game score changed.
+//
+// KEYS[1] game board, KEYS[2] version hash, KEYS[3] regional board and
+// KEYS[4] regions set (both optional)
+// ARGV[1] score, ARGV[2] member, ARGV[3] current time in Unix milliseconds, ARGV[4] "1" to keep the member,
+// ARGV[5] region
+var replaceScript = redis.NewScript(`
+local old = tonumber(redis.call('ZSCORE', KEYS[1], ARGV[2]) or 0)
+local new = 0
+if ARGV[4] == '1' then
+	new = tonumber(ARGV[1])
+	redis.call('ZADD', KEYS[1], new, ARGV[2])
+	if KEYS[3] then
+		redis.call('ZADD', KEYS[3], new, ARGV[2])
+		redis.call('SADD', KEYS[4], ARGV[5])
+	end
+else
+	redis.call('ZREM', KEYS[1], ARGV[2])
+	if KEYS[3] then
+		redis.call('ZREM', KEYS[3], ARGV[2])
+	end
+end
+redis.call('HINCRBY', KEYS[2], 'version', 1)
+redis.call('HSET', KEYS[2], 'updated_at', ARGV[3])
+return new - old
+`)
+
+// removeMemberScript removes a member from a game board and its regional
+// variant, subtracts the member's game score from the global boards and bumps
+// the board version. It returns the removed score.
+//
+// KEYS[1] game board, KEYS[2] version hash, KEYS[3] global board,
+// KEYS[4] regional game board, KEYS[5] regional global board (both optional)
+// ARGV[1] member, ARGV[2] current time in Unix milliseconds
+var removeMemberScript = redis.NewScript(`
+local score = redis.call('ZSCORE', KEYS[1], ARGV[1])
+if not score then
+	return 0
+end
+redis.call('ZREM', KEYS[1], ARGV[1])
+redis.call('ZINCRBY', KEYS[3], -score, ARGV[1])
+if KEYS[4] then
+	redis.call('ZREM', KEYS[4], ARGV[1])
+	redis.call('ZINCRBY', KEYS[5], -score, ARGV[1])
+end
+redis.call('HINCRBY', KEYS[2], 'version', 1)
+redis.call('HSET', KEYS[2], 'updated_at', ARGV[2])
+return tonumber(score)
+`)
+
+// moveRegionScript copies a member's score on each board into its regional
+// variant for the new region and removes the member from the variant for the
+// old region, then bumps the versions of the game boards. Reading and writing
+// in one script keeps a concurrent score update from being lost in between.
+//
+// KEYS in triples of board, old regional board, new regional board, followed
+// by the version hash and regions set of each game board
+// ARGV[1] member, ARGV[2] current time in Unix milliseconds, ARGV[3] number of
+// boards, ARGV[4] "1" to remove from the old region, ARGV[5] "1" to add to the
+// new region, ARGV[6] new region
+var moveRegionScript = redis.NewScript(`
+local boards = tonumber(ARGV[3])
+for i = 1, boards do
+	if ARGV[4] == '1' then
+		redis.call('ZREM', KEYS[3 * i - 1], ARGV[1])
+	end
+	local score = redis.call('ZSCORE', KEYS[3 * i - 2], ARGV[1])
+	if score and ARGV[5] == '1' then
+		redis.call('ZADD', KEYS[3 * i], score, ARGV[1])
+	end
+end
+for i = 3 * boards + 1, #KEYS, 2 do
+	redis.call('HINCRBY', KEYS[i], 'version', 1)
+	redis.call('HSET', KEYS[i], 'updated_at', ARGV[2])
+	if ARGV[5] == '1' then
+		redis.call('SADD', KEYS[i + 1], ARGV[6])
+	end
+end
+return 0
+`)
+
+// pageAfterScript returns the rows that follow a cursor. The rows sharing the
+// cursor's score sit between the ranks ZCOUNT gives, ordered by member in
+// reverse lexicographical order, so the first row past the cursor is found by
+// a binary search over those ranks instead of reading every tie. Members are
+// compared byte by byte, the way Redis orders them, rather than with Lua's
+// locale-dependent string comparison.
+//
+// KEYS[1] board
+// ARGV[1] cursor score, ARGV[2] cursor member, ARGV[3] limit
+// Returns the number of rows ahead of the page, the board size, then the
+// page's members and scores.
+var pageAfterScript = redis.NewScript(`
+local function sortsBefore(a, b)
+	local n = math.min(#a, #b)
+	for i = 1, n do
+		local x, y = string.byte(a, i), string.byte(b, i)
+		if x ~= y then
+			return x < y
+		end
+	end
+	return #a < #b
+end
+local lo = redis.call('ZCOUNT', KEYS[1], '(' .. ARGV[1], '+inf')
+local hi = lo + redis.call('ZCOUNT', KEYS[1], ARGV[1], ARGV[1])
+while lo < hi do
+	local mid = math.floor((lo + hi) / 2)
+	local member = redis.call('ZREVRANGE', KEYS[1], mid, mid)[1]
+	if sortsBefore(member, ARGV[2]) then
+		hi = mid
+	else
+		lo = mid + 1
+	end
+end
+local result = {lo, redis.call('ZCARD', KEYS[1])}
+local rows = redis.call('ZREVRANGE', KEYS[1], lo, lo + tonumber(ARGV[3]) - 1, 'WITHSCORES')
+for _, v in ipairs(rows) do
+	result[#result + 1] = v
+end
+return result
+`)
+
+// drainScript moves a batch of members off a game board, subtracting each
+// member's score from a global board. Members stay on the global board even at
+// zero, since a zero score does not mean they have no other game left. Every
+// member is subtracted and removed in one step, so a purge interrupted
+// half-way can be run again without subtracting any score twice. It returns
+// the number of members moved.
+//
+// KEYS[1] game board, KEYS[2] global board
+// ARGV[1] batch size
+var drainScript = redis.NewScript(`
+local rows = redis.call('ZRANGE', KEYS[1], 0, tonumber(ARGV[1]) - 1, 'WITHSCORES')
+for i = 1, #rows, 2 do
+	redis.call('ZINCRBY', KEYS[2], -tonumber(rows[i + 1]), rows[i])
+	redis.call('ZREM', KEYS[1], rows[i])
+end
+return #rows / 2
+`)
+
+// purgeBatch is the number of board members processed per round trip when a game is purged.
+const purgeBatch = 1000
+
 type LeaderboardRepo struct {
 	db  *sqlx.DB
 	rdb *redis.Client
@@ -20,7 +228,7 @@ type LeaderboardRepo struct {
 func NewLeaderboardRepo(db *sqlx.DB, rdb *redis.Client, log *logger.SlogLogger) *LeaderboardRepo {
 	return &LeaderboardRepo{db: db, rdb: rdb, log: log}
 }
-func (r *LeaderboardRepo) IncrementGameScore(ctx context.Context, gameID string, userID string, score int) error {
+func (r *LeaderboardRepo) IncrementGameScore(ctx context.Context, gameID string, userID string, region string, score int) error {
 
 	if gameID == "" {
 		return fmt.Errorf("gameID must not be empty")
@@ -28,100 +236,435 @@ func (r *LeaderboardRepo) IncrementGameScore(ctx context.Context, gameID string,
 	if userID == "" {
 		return fmt.Errorf("userID must not be empty")
 	}
-	key := fmt.Sprintf("leaderboard:game:%s", gameID)
+	key := gameKey(gameID)
 
-	return r.rdb.ZIncrBy(ctx, key, float64(score), userID).Err()
+	_, err := r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZIncrBy(ctx, key, float64(score), userID)
+		if region != "" {
+			pipe.ZIncrBy(ctx, regionKey(key, region), float64(score), userID)
+			pipe.SAdd(ctx, regionsKey(gameID), region)
+		}
+		bumpVersion(ctx, pipe, gameID)
+		return nil
+	})
+	return err
 }
 
-func (r *LeaderboardRepo) IncrementGlobalScore(ctx context.Context, userID string, score int) error {
-	return r.rdb.ZIncrBy(
-		ctx,
-		"leaderboard:global",
-		float64(score),
-		userID,
-	).Err()
+// SetBestGameScore records the score on the game board only if it beats the
+// member's current one and returns the increase, which the caller adds to the
+// global boards.
+func (r *LeaderboardRepo) SetBestGameScore(ctx context.Context, gameID string, userID string, region string, score int) (int64, error) {
+	if gameID == "" {
+		return 0, fmt.Errorf("gameID must not be empty")
+	}
+	if userID == "" {
+		return 0, fmt.Errorf("userID must not be empty")
+	}
+	key := gameKey(gameID)
+	keys := []string{key, versionKey(gameID)}
+	if region != "" {
+		keys = append(keys, regionKey(key, region), regionsKey(gameID))
+	}
+	return setBestScript.Run(ctx, r.rdb, keys, score, userID, time.Now().UnixMilli(), region).Int64()
 }
-func (r *LeaderboardRepo) GetGlobal(ctx context.Context, offset int, limit int) ([]domain.LeaderboardUser, error) {
-	start := int64(offset)
-	stop := int64(offset + limit - 1)
 
-	values, err := r.rdb.ZRevRangeWithScores(
-		ctx,
-		"leaderboard:global",
-		start,
-		stop,
-	).Result()
-	if err != nil {
-		return nil, err
+// ReplaceGameScore overwrites the member's game score, or removes the member
+// from the game board when keep is false, and returns the change, which the
+// caller applies to the global boards.
+func (r *LeaderboardRepo) ReplaceGameScore(ctx context.Context, gameID string, userID string, region string, score int64, keep bool) (int64, error) {
+	if gameID == "" {
+		return 0, fmt.Errorf("gameID must not be empty")
+	}
+	if userID == "" {
+		return 0, fmt.Errorf("userID must not be empty")
+	}
+	key := gameKey(gameID)
+	keys := []string{key, versionKey(gameID)}
+	if region != "" {
+		keys = append(keys, regionKey(key, region), regionsKey(gameID))
 	}
+	keepArg := "0"
+	if keep {
+		keepArg = "1"
+	}
+	return replaceScript.Run(ctx, r.rdb, keys, score, userID, time.Now().UnixMilli(), keepArg, region).Int64()
+}
 
-	result := make([]domain.LeaderboardUser, 0, len(values))
+// RemoveMember takes the user off the given game boards, subtracting their game
+// scores from the global boards. With global set the user is also removed from
+// the global boards. Each step is idempotent, so a failed removal can be retried.
+func (r *LeaderboardRepo) RemoveMember(ctx context.Context, gameIDs []uuid.UUID, userID string, region string, global bool) error {
+	if userID == "" {
+		return fmt.Errorf("userID must not be empty")
+	}
+	now := time.Now().UnixMilli()
+	for _, gameID := range gameIDs {
+		key := gameKey(gameID.String())
+		keys := []string{key, versionKey(gameID.String()), globalKey}
+		if region != "" {
+			keys = append(keys, regionKey(key, region), regionKey(globalKey, region))
+		}
+		if err := removeMemberScript.Run(ctx, r.rdb, keys, userID, now).Err(); err != nil && !errors.Is(err, redis.Nil) {
+			return err
+		}
+	}
+	if !global {
+		return nil
+	}
+	_, err := r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRem(ctx, globalKey, userID)
+		if region != "" {
+			pipe.ZRem(ctx, regionKey(globalKey, region), userID)
+		}
+		return nil
+	})
+	return err
+}
 
-	for _, v := range values {
-		userID, err := uuid.Parse(v.Member.(string))
-		if err != nil {
-			continue
+// SetGlobalScore overwrites the user's score on the global boards.
+func (r *LeaderboardRepo) SetGlobalScore(ctx context.Context, userID string, region string, score int64) error {
+	_, err := r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZAdd(ctx, globalKey, &redis.Z{Score: float64(score), Member: userID})
+		if region != "" {
+			pipe.ZAdd(ctx, regionKey(globalKey, region), &redis.Z{Score: float64(score), Member: userID})
 		}
+		return nil
+	})
+	return err
+}
 
-		rank, err := r.rdb.ZRevRank(ctx, "leaderboard:global", v.Member.(string)).Result()
-		if err != nil {
-			continue
+func (r *LeaderboardRepo) IncrementGlobalScore(ctx context.Context, userID string, region string, score int) error {
+	_, err := r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZIncrBy(ctx, globalKey, float64(score), userID)
+		if region != "" {
+			pipe.ZIncrBy(ctx, regionKey(globalKey, region), float64(score), userID)
 		}
+		return nil
+	})
+	return err
+}
+func (r *LeaderboardRepo) GetGlobal(ctx context.Context, region string, page domain.PageRequest) (domain.LeaderboardPage, error) {
+	return r.getPage(ctx, regionKey(globalKey, region), page)
+}
 
-		result = append(result, domain.LeaderboardUser{
-			UserID: userID,
-			Score:  int64(v.Score),
-			Rank:   rank + 1, // ✅ реальный глобальный ранг
-		})
+func (r *LeaderboardRepo) GetMyRank(ctx context.Context, userID uuid.UUID, region string) (domain.RankStats, error) {
+	return r.rankStats(ctx, regionKey(globalKey, region), userID.String())
+}
+
+func (r *LeaderboardRepo) GetGameRank(ctx context.Context, gameID uuid.UUID, userID uuid.UUID, region string) (domain.RankStats, error) {
+	if gameID == uuid.Nil {
+		return domain.RankStats{}, fmt.Errorf("gameID must not be empty")
 	}
+	return r.rankStats(ctx, regionKey(gameKey(gameID.String()), region), userID.String())
+}
 
-	return result, nil
+// rankStats fetches the member's rank and the board size in one round trip.
+func (r *LeaderboardRepo) rankStats(ctx context.Context, key string, member string) (domain.RankStats, error) {
+	var rank *redis.IntCmd
+	var total *redis.IntCmd
+	_, err := r.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		rank = pipe.ZRevRank(ctx, key, member)
+		total = pipe.ZCard(ctx, key)
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return domain.RankStats{}, err
+	}
+
+	stats := domain.RankStats{Rank: -1, Total: total.Val()}
+	if pos, err := rank.Result(); err == nil {
+		stats.Rank = pos + 1
+	} else if !errors.Is(err, redis.Nil) {
+		return domain.RankStats{}, err
+	}
+	return stats, nil
 }
 
-func (r *LeaderboardRepo) GetMyRank(ctx context.Context, userID uuid.UUID) (int64, error) {
-	rank, err := r.rdb.ZRevRank(ctx, "leaderboard:global", userID.String()).Result()
-	if errors.Is(err, redis.Nil) {
-		return -1, nil
+// GetDistribution builds a histogram of the game board's scores with ZCOUNT.
+// The result is cached for distributionTTL so that repeated requests on large
+// boards cost a single GET.
+func (r *LeaderboardRepo) GetDistribution(ctx context.Context, gameID uuid.UUID, region string, buckets int) (domain.ScoreDistribution, error) {
+	if gameID == uuid.Nil {
+		return domain.ScoreDistribution{}, fmt.Errorf("gameID must not be empty")
+	}
+	if buckets <= 0 {
+		return domain.ScoreDistribution{}, fmt.Errorf("buckets must be positive")
+	}
+	key := regionKey(gameKey(gameID.String()), region)
+	cacheKey := fmt.Sprintf("%s:distribution:%d", key, buckets)
+
+	var dist domain.ScoreDistribution
+	cached, err := r.rdb.Get(ctx, cacheKey).Bytes()
+	if err == nil {
+		if err := json.Unmarshal(cached, &dist); err == nil {
+			return dist, nil
+		}
+	} else if !errors.Is(err, redis.Nil) {
+		return domain.ScoreDistribution{}, err
 	}
+
+	var total *redis.IntCmd
+	var lowest, highest *redis.ZSliceCmd
+	_, err = r.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		total = pipe.ZCard(ctx, key)
+		lowest = pipe.ZRangeWithScores(ctx, key, 0, 0)
+		highest = pipe.ZRevRangeWithScores(ctx, key, 0, 0)
+		return nil
+	})
 	if err != nil {
-		return 0, err
+		return domain.ScoreDistribution{}, err
+	}
+
+	dist = domain.ScoreDistribution{Total: total.Val(), Buckets: []domain.ScoreBucket{}}
+	if dist.Total > 0 && len(lowest.Val()) > 0 && len(highest.Val()) > 0 {
+		dist.Min = int64(lowest.Val()[0].Score)
+		dist.Max = int64(highest.Val()[0].Score)
+
+		width := (dist.Max - dist.Min + int64(buckets)) / int64(buckets)
+		counts := make([]*redis.IntCmd, 0, buckets)
+		_, err = r.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for from := dist.Min; from <= dist.Max; from += width {
+				to := min(from+width-1, dist.Max)
+				dist.Buckets = append(dist.Buckets, domain.ScoreBucket{From: from, To: to})
+				counts = append(counts, pipe.ZCount(ctx, key, strconv.FormatInt(from, 10), strconv.FormatInt(to, 10)))
+			}
+			return nil
+		})
+		if err != nil {
+			return domain.ScoreDistribution{}, err
+		}
+		for i, count := range counts {
+			dist.Buckets[i].Count = count.Val()
+		}
+	}
+
+	if payload, err := json.Marshal(dist); err == nil {
+		if err := r.rdb.Set(ctx, cacheKey, payload, distributionTTL).Err(); err != nil {
+			r.log.Warn(ctx, "redis cache distribution error", err.Error())
+		}
 	}
-	return rank + 1, nil
+	return dist, nil
 }
-func (r *LeaderboardRepo) GetLeaderboard(ctx context.Context, gameID uuid.UUID) ([]domain.LeaderboardUser, error) {
+
+func (r *LeaderboardRepo) GetLeaderboard(ctx context.Context, gameID uuid.UUID, region string, page domain.PageRequest) (domain.LeaderboardPage, error) {
 	if gameID == uuid.Nil {
-		return nil, fmt.Errorf("gameID must not be empty")
+		return domain.LeaderboardPage{}, fmt.Errorf("gameID must not be empty")
 	}
-	key := fmt.Sprintf("leaderboard:game:%s", gameID.String())
+	return r.getPage(ctx, regionKey(gameKey(gameID.String()), region), page)
+}
 
-	values, err := r.rdb.ZRevRangeWithScores(ctx, key, 0, -1).Result()
+func (r *LeaderboardRepo) getPage(ctx context.Context, key string, page domain.PageRequest) (domain.LeaderboardPage, error) {
+	if page.Limit <= 0 {
+		return domain.LeaderboardPage{}, fmt.Errorf("limit must be positive")
+	}
+	if page.Cursor != nil {
+		return r.pageAfter(ctx, key, *page.Cursor, page.Limit)
+	}
+	return r.pageAt(ctx, key, page.Offset, page.Limit)
+}
+
+// pageAt returns the page starting at the given offset. Ranks are derived from
+// the offset instead of a ZREVRANK per row: they are ordinal, with ties ordered
+// by member exactly as ZREVRANK orders them, so a page costs one round trip
+// whatever its size.
+func (r *LeaderboardRepo) pageAt(ctx context.Context, key string, offset int, limit int) (domain.LeaderboardPage, error) {
+	var values *redis.ZSliceCmd
+	var total *redis.IntCmd
+	_, err := r.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		values = pipe.ZRevRangeWithScores(ctx, key, int64(offset), int64(offset+limit-1))
+		total = pipe.ZCard(ctx, key)
+		return nil
+	})
 	if err != nil {
-		return nil, err
+		return domain.LeaderboardPage{}, err
 	}
 
+	result := toLeaderboardUsers(values.Val(), int64(offset))
+	return newPage(values.Val(), result, limit, int64(offset+len(values.Val())), total.Val()), nil
+}
+
+// pageAfter returns the page that follows the cursor. The cursor row need not
+// be on the board any more: the page starts at the first row ordered after it,
+// so rows are neither skipped nor repeated. The script bounds the work to the
+// page plus a binary search over the rows tied with the cursor.
+func (r *LeaderboardRepo) pageAfter(ctx context.Context, key string, cursor domain.Cursor, limit int) (domain.LeaderboardPage, error) {
+	score := strconv.FormatFloat(cursor.Score, 'f', -1, 64)
+	reply, err := pageAfterScript.Run(ctx, r.rdb, []string{key}, score, cursor.Member, limit).Slice()
+	if err != nil {
+		return domain.LeaderboardPage{}, err
+	}
+	if len(reply) < 2 {
+		return domain.LeaderboardPage{}, fmt.Errorf("unexpected page reply of %d values", len(reply))
+	}
+	skipped, _ := reply[0].(int64)
+	total, _ := reply[1].(int64)
+
+	values := make([]redis.Z, 0, (len(reply)-2)/2)
+	for i := 2; i+1 < len(reply); i += 2 {
+		member, _ := reply[i].(string)
+		raw, _ := reply[i+1].(string)
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return domain.LeaderboardPage{}, fmt.Errorf("parse score of %q: %w", member, err)
+		}
+		values = append(values, redis.Z{Member: member, Score: value})
+	}
+
+	result := toLeaderboardUsers(values, skipped)
+	return newPage(values, result, limit, skipped+int64(len(values)), total), nil
+}
+
+// toLeaderboardUsers converts a contiguous range of board rows, the first of
+// which has skipped rows ahead of it, into ranked leaderboard entries.
+func toLeaderboardUsers(values []redis.Z, skipped int64) []domain.LeaderboardUser {
 	result := make([]domain.LeaderboardUser, 0, len(values))
-	for _, v := range values {
+	for i, v := range values {
 		memberStr, ok := v.Member.(string)
 		if !ok {
 			continue
 		}
-
 		userID, err := uuid.Parse(memberStr)
 		if err != nil {
 			continue
 		}
-
-		rank, err := r.rdb.ZRevRank(ctx, key, memberStr).Result()
-		if err != nil {
-			continue
-		}
-
 		result = append(result, domain.LeaderboardUser{
 			UserID: userID,
 			Score:  int64(v.Score),
-			Rank:   rank + 1,
+			Rank:   skipped + int64(i) + 1,
 		})
 	}
+	return result
+}
+
+// newPage wraps a page of rows and sets the cursor to the last row when more rows follow.
+func newPage(values []redis.Z, users []domain.LeaderboardUser, limit int, consumed int64, total int64) domain.LeaderboardPage {
+	page := domain.LeaderboardPage{Users: users, Total: total}
+	if len(values) == limit && consumed < total {
+		last := values[len(values)-1]
+		member, _ := last.Member.(string)
+		page.NextCursor = domain.Cursor{Score: last.Score, Member: member}.Encode()
+	}
+	return page
+}
+
+// BoardSizes returns the number of players on the global board and on each of
+// the given game boards, keyed by "global" and the game id.
+func (r *LeaderboardRepo) BoardSizes(ctx context.Context, gameIDs []uuid.UUID) (map[string]int64, error) {
+	cmds := make(map[string]*redis.IntCmd, len(gameIDs)+1)
+	_, err := r.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		cmds["global"] = pipe.ZCard(ctx, globalKey)
+		for _, id := range gameIDs {
+			cmds[id.String()] = pipe.ZCard(ctx, gameKey(id.String()))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sizes := make(map[string]int64, len(cmds))
+	for board, cmd := range cmds {
+		sizes[board] = cmd.Val()
+	}
+	return sizes, nil
+}
+
+// GetBoardVersion returns the version of a game board; a board that was never
+// written to has version 0.
+func (r *LeaderboardRepo) GetBoardVersion(ctx context.Context, gameID uuid.UUID) (domain.BoardVersion, error) {
+	values, err := r.rdb.HMGet(ctx, versionKey(gameID.String()), "version", "updated_at").Result()
+	if err != nil {
+		return domain.BoardVersion{}, err
+	}
 
-	return result, nil
+	var version domain.BoardVersion
+	if v, ok := values[0].(string); ok {
+		version.Version, _ = strconv.ParseInt(v, 10, 64)
+	}
+	if v, ok := values[1].(string); ok {
+		if ms, err := strconv.ParseInt(v, 10, 64); err == nil {
+			version.UpdatedAt = time.UnixMilli(ms).UTC()
+		}
+	}
+	return version, nil
+}
+
+// PurgeGame subtracts the game's contribution from the global and regional
+// boards, keeping every player on them, and deletes the game's board,
+// regional boards, version and regions set. The regional boards are
+// the ones listed in the regions set. Cached distributions expire on their
+// own. A purge that failed half-way can be run again.
+func (r *LeaderboardRepo) PurgeGame(ctx context.Context, gameID uuid.UUID) error {
+	if gameID == uuid.Nil {
+		return fmt.Errorf("gameID must not be empty")
+	}
+	id := gameID.String()
+	key := gameKey(id)
+	regions, err := r.rdb.SMembers(ctx, regionsKey(id)).Result()
+	if err != nil {
+		return err
+	}
+	if err := r.drainBoard(ctx, key, globalKey); err != nil {
+		return err
+	}
+	for _, region := range regions {
+		if err := r.drainBoard(ctx, regionKey(key, region), regionKey(globalKey, region)); err != nil {
+			return err
+		}
+	}
+	if err := r.rdb.Del(ctx, versionKey(id), regionsKey(id)).Err(); err != nil {
+		r.log.Error(ctx, "redis purge game error", err.Error())
+		return err
+	}
+	return nil
+}
+
+// drainBoard empties board in batches, subtracting its scores from target.
+func (r *LeaderboardRepo) drainBoard(ctx context.Context, board string, target string) error {
+	for {
+		moved, err := drainScript.Run(ctx, r.rdb, []string{board, target}, purgeBatch).Int64()
+		if err != nil {
+			r.log.Error(ctx, "redis drain board error", err.Error(), "board", board)
+			return err
+		}
+		if moved < purgeBatch {
+			return nil
+		}
+	}
+}
+
+// MoveRegion moves the user's scores from the boards of one region to another.
+// The non-regional boards are the source of truth: the user's current score on
+// the global board and on each of the given game boards is copied into the new
+// region and removed from the old one by a single script. Moving again between
+// the same regions changes nothing but the board versions.
+func (r *LeaderboardRepo) MoveRegion(ctx context.Context, userID uuid.UUID, gameIDs []uuid.UUID, from string, to string) error {
+	if from == to {
+		return nil
+	}
+	boards := make([]string, 0, len(gameIDs)+1)
+	boards = append(boards, globalKey)
+	for _, gameID := range gameIDs {
+		boards = append(boards, gameKey(gameID.String()))
+	}
+
+	keys := make([]string, 0, 3*len(boards)+2*len(gameIDs))
+	for _, board := range boards {
+		keys = append(keys, board, regionKey(board, from), regionKey(board, to))
+	}
+	for _, gameID := range gameIDs {
+		keys = append(keys, versionKey(gameID.String()), regionsKey(gameID.String()))
+	}
+	removeArg, addArg := "0", "0"
+	if from != "" {
+		removeArg = "1"
+	}
+	if to != "" {
+		addArg = "1"
+	}
+	err := moveRegionScript.Run(ctx, r.rdb, keys, userID.String(), time.Now().UnixMilli(), len(boards), removeArg, addArg, to).Err()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		r.log.Error(ctx, "redis move region error", err.Error())
+		return err
+	}
+	return nil
 }
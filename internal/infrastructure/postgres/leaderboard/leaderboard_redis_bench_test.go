@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"OnlineLeadership/internal/domain"
+	"OnlineLeadership/internal/infrastructure/logger"
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+const benchBoardSize = 5000
+
+var benchPageSizes = []int{10, 100, 1000}
+
+// roundTrips counts network round trips: a single command and a whole pipeline count as one each.
+type roundTrips struct {
+	n atomic.Int64
+}
+
+func (c *roundTrips) BeforeProcess(ctx context.Context, _ redis.Cmder) (context.Context, error) {
+	c.n.Add(1)
+	return ctx, nil
+}
+
+func (c *roundTrips) AfterProcess(context.Context, redis.Cmder) error {
+	return nil
+}
+
+func (c *roundTrips) BeforeProcessPipeline(ctx context.Context, _ []redis.Cmder) (context.Context, error) {
+	c.n.Add(1)
+	return ctx, nil
+}
+
+func (c *roundTrips) AfterProcessPipeline(context.Context, []redis.Cmder) error {
+	return nil
+}
+
+// newBenchRepo starts an in-process Redis stand-in holding a global board and
+// one game board of benchBoardSize players each.
+func newBenchRepo(b testing.TB) (*LeaderboardRepo, *roundTrips, uuid.UUID) {
+	b.Helper()
+	mr := miniredis.RunT(b)
+	gameID := uuid.New()
+	for i := 0; i < benchBoardSize; i++ {
+		member := uuid.NewString()
+		// Every score is shared by ten players so that ties are exercised.
+		score := float64(i / 10)
+		if _, err := mr.ZAdd(globalKey, score, member); err != nil {
+			b.Fatal(err)
+		}
+		if _, err := mr.ZAdd(gameKey(gameID.String()), score, member); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	counter := &roundTrips{}
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	rdb.AddHook(counter)
+	b.Cleanup(func() { _ = rdb.Close() })
+
+	return NewLeaderboardRepo(nil, rdb, logger.New("info", logger.FormatJSON)), counter, gameID
+}
+
+// reportRoundTrips publishes the round trips per listing and fails the
+// benchmark when it exceeds want, whatever the page size.
+func reportRoundTrips(b *testing.B, counter *roundTrips, want int64) {
+	b.Helper()
+	perOp := counter.n.Load() / int64(b.N)
+	b.ReportMetric(float64(perOp), "round-trips/op")
+	if perOp > want {
+		b.Fatalf("listing cost %d round trips, want at most %d", perOp, want)
+	}
+}
+
+// TestListingRoundTrips checks the round-trip budgets of the benchmarks with
+// a plain go test run: every listing, whatever its page size, is one round trip.
+func TestListingRoundTrips(t *testing.T) {
+	repo, counter, gameID := newBenchRepo(t)
+	ctx := context.Background()
+
+	first, err := repo.GetLeaderboard(ctx, gameID, "", domain.PageRequest{Offset: benchBoardSize / 2, Limit: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cursor, err := domain.DecodeCursor(first.NextCursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, size := range benchPageSizes {
+		tests := []struct {
+			name string
+			list func() error
+		}{
+			{"global offset", func() error {
+				_, err := repo.GetGlobal(ctx, "", domain.PageRequest{Offset: benchBoardSize / 2, Limit: size})
+				return err
+			}},
+			{"game cursor", func() error {
+				_, err := repo.GetLeaderboard(ctx, gameID, "", domain.PageRequest{Cursor: &cursor, Limit: size})
+				return err
+			}},
+		}
+		for _, tt := range tests {
+			t.Run(fmt.Sprintf("%s/page=%d", tt.name, size), func(t *testing.T) {
+				// The first call may load a Lua script; only the steady state is budgeted.
+				if err := tt.list(); err != nil {
+					t.Fatal(err)
+				}
+				counter.n.Store(0)
+				if err := tt.list(); err != nil {
+					t.Fatal(err)
+				}
+				if n := counter.n.Load(); n != 1 {
+					t.Fatalf("listing cost %d round trips, want 1", n)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkGetGlobalOffset(b *testing.B) {
+	for _, size := range benchPageSizes {
+		b.Run(fmt.Sprintf("page=%d", size), func(b *testing.B) {
+			repo, counter, _ := newBenchRepo(b)
+			ctx := context.Background()
+			page := domain.PageRequest{Offset: benchBoardSize / 2, Limit: size}
+
+			counter.n.Store(0)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := repo.GetGlobal(ctx, "", page); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.StopTimer()
+			reportRoundTrips(b, counter, 1)
+		})
+	}
+}
+
+func BenchmarkGetLeaderboardCursor(b *testing.B) {
+	for _, size := range benchPageSizes {
+		b.Run(fmt.Sprintf("page=%d", size), func(b *testing.B) {
+			repo, counter, gameID := newBenchRepo(b)
+			ctx := context.Background()
+
+			first, err := repo.GetLeaderboard(ctx, gameID, "", domain.PageRequest{Offset: benchBoardSize / 2, Limit: 5})
+			if err != nil {
+				b.Fatal(err)
+			}
+			cursor, err := domain.DecodeCursor(first.NextCursor)
+			if err != nil {
+				b.Fatal(err)
+			}
+			page := domain.PageRequest{Cursor: &cursor, Limit: size}
+			// The first cursor page loads the Lua script into Redis, which costs
+			// one extra round trip per process; later pages run it by its SHA.
+			if _, err := repo.GetLeaderboard(ctx, gameID, "", page); err != nil {
+				b.Fatal(err)
+			}
+
+			counter.n.Store(0)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := repo.GetLeaderboard(ctx, gameID, "", page); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.StopTimer()
+			reportRoundTrips(b, counter, 1)
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package repository
+
+import (
+	"math/rand/v2"
+)
+
+const (
+	skipListMaxLevel = 32
+	// skipListP is the chance that a node is promoted to the next level.
+	skipListP = 0.25
+)
+
+// skipNode is one board row. Each forward link records its span: the number
+// of rows it skips over, which is what makes ranks and offsets O(log n).
+type skipNode struct {
+	member string
+	score  float64
+	next   []skipLink
+}
+
+type skipLink struct {
+	node *skipNode
+	span int
+}
+
+// skipList is a sorted set ordered the way ZREVRANGE orders a Redis sorted
+// set: by score, highest first, with ties ordered by member in reverse
+// lexicographical order. Positions are 1-based. It is not safe for concurrent
+// use.
+type skipList struct {
+	head   *skipNode
+	level  int
+	length int
+	scores map[string]float64
+}
+
+func newSkipList() *skipList {
+	return &skipList{
+		head:   &skipNode{next: make([]skipLink, skipListMaxLevel)},
+		level:  1,
+		scores: make(map[string]float64),
+	}
+}
+
+// before reports whether n comes before the row (score, member).
+func (n *skipNode) before(score float64, member string) bool {
+	return n.score > score || n.score == score && n.member > member
+}
+
+func randomLevel() int {
+	level := 1
+	for level < skipListMaxLevel && rand.Float64() < skipListP {
+		level++
+	}
+	return level
+}
+
+// Len returns the number of rows.
+func (l *skipList) Len() int {
+	return l.length
+}
+
+// Score returns the member's score and whether the member is on the list.
+func (l *skipList) Score(member string) (float64, bool) {
+	score, ok := l.scores[member]
+	return score, ok
+}
+
+// Set adds the member with the given score or moves it to its new score.
+func (l *skipList) Set(member string, score float64) {
+	if old, ok := l.scores[member]; ok {
+		if old == score {
+			return
+		}
+		l.delete(member, old)
+	}
+	l.insert(member, score)
+}
+
+// Incr adds delta to the member's score, adding the member at delta if it is
+// not on the list, and returns the new score.
+func (l *skipList) Incr(member string, delta float64) float64 {
+	score := l.scores[member] + delta
+	l.Set(member, score)
+	return score
+}
+
+// Remove takes the member off the list and reports whether it was on it.
+func (l *skipList) Remove(member string) bool {
+	score, ok := l.scores[member]
+	if ok {
+		l.delete(member, score)
+	}
+	return ok
+}
+
+func (l *skipList) insert(member string, score float64) {
+	var update [skipListMaxLevel]*skipNode
+	var rank [skipListMaxLevel]int
+	x := l.head
+	for i := l.level - 1; i >= 0; i-- {
+		if i < l.level-1 {
+			rank[i] = rank[i+1]
+		}
+		for x.next[i].node != nil && x.next[i].node.before(score, member) {
+			rank[i] += x.next[i].span
+			x = x.next[i].node
+		}
+		update[i] = x
+	}
+
+	level := randomLevel()
+	if level > l.level {
+		for i := l.level; i < level; i++ {
+			update[i] = l.head
+			update[i].next[i].span = l.length
+		}
+		l.level = level
+	}
+	n := &skipNode{member: member, score: score, next: make([]skipLink, level)}
+	for i := 0; i < level; i++ {
+		n.next[i].node = update[i].next[i].node
+		update[i].next[i].node = n
+		n.next[i].span = update[i].next[i].span - (rank[0] - rank[i])
+		update[i].next[i].span = rank[0] - rank[i] + 1
+	}
+	for i := level; i < l.level; i++ {
+		update[i].next[i].span++
+	}
+	l.length++
+	l.scores[member] = score
+}
+
+func (l *skipList) delete(member string, score float64) {
+	var update [skipListMaxLevel]*skipNode
+	x := l.head
+	for i := l.level - 1; i >= 0; i-- {
+		for x.next[i].node != nil && x.next[i].node.before(score, member) {
+			x = x.next[i].node
+		}
+		update[i] = x
+	}
+	n := x.next[0].node
+	if n == nil || n.member != member {
+		return
+	}
+	for i := 0; i < l.level; i++ {
+		if update[i].next[i].node == n {
+			update[i].next[i].span += n.next[i].span - 1
+			update[i].next[i].node = n.next[i].node
+		} else {
+			update[i].next[i].span--
+		}
+	}
+	for l.level > 1 && l.head.next[l.level-1].node == nil {
+		l.level--
+	}
+	l.length--
+	delete(l.scores, member)
+}
+
+// countWhile returns the number of leading rows for which before holds; before
+// must hold for a prefix of the list only.
+func (l *skipList) countWhile(before func(n *skipNode) bool) int {
+	count := 0
+	x := l.head
+	for i := l.level - 1; i >= 0; i-- {
+		for x.next[i].node != nil && before(x.next[i].node) {
+			count += x.next[i].span
+			x = x.next[i].node
+		}
+	}
+	return count
+}
+
+// Rank returns the member's 1-based position, or 0 if it is not on the list.
+func (l *skipList) Rank(member string) int {
+	score, ok := l.scores[member]
+	if !ok {
+		return 0
+	}
+	return l.Through(score, member)
+}
+
+// Through returns the number of rows up to and including the position of
+// (score, member), whether or not that row is on the list.
+func (l *skipList) Through(score float64, member string) int {
+	return l.countWhile(func(n *skipNode) bool {
+		return n.before(score, member) || n.score == score && n.member == member
+	})
+}
+
+// Above returns the number of rows scoring more than score.
+func (l *skipList) Above(score float64) int {
+	return l.countWhile(func(n *skipNode) bool {
+		return n.score > score
+	})
+}
+
+// Count returns the number of rows scoring between from and to inclusive.
+func (l *skipList) Count(from, to float64) int {
+	if from > to {
+		return 0
+	}
+	atLeast := l.countWhile(func(n *skipNode) bool {
+		return n.score >= from
+	})
+	return atLeast - l.Above(to)
+}
+
+// at returns the node at the 1-based position, or nil past the end.
+func (l *skipList) at(pos int) *skipNode {
+	if pos < 1 || pos > l.length {
+		return nil
+	}
+	traversed := 0
+	x := l.head
+	for i := l.level - 1; i >= 0; i-- {
+		for x.next[i].node != nil && traversed+x.next[i].span <= pos {
+			traversed += x.next[i].span
+			x = x.next[i].node
+		}
+		if traversed == pos {
+			return x
+		}
+	}
+	return nil
+}
+
+// boardRow is a member and its score.
+type boardRow struct {
+	Member string
+	Score  float64
+}
+
+// Range returns up to limit rows starting after the first skip rows.
+func (l *skipList) Range(skip int, limit int) []boardRow {
+	rows := make([]boardRow, 0, min(limit, max(l.length-skip, 0)))
+	for n := l.at(skip + 1); n != nil && len(rows) < limit; n = n.next[0].node {
+		rows = append(rows, boardRow{Member: n.member, Score: n.score})
+	}
+	return rows
+}
+
+// Each calls fn for every row in order.
+func (l *skipList) Each(fn func(member string, score float64)) {
+	for n := l.head.next[0].node; n != nil; n = n.next[0].node {
+		fn(n.member, n.score)
+	}
+}
@@ -0,0 +1,194 @@
+package migration
+
+import (
+	"OnlineLeadership/internal/infrastructure/logger"
+	"OnlineLeadership/internal/infrastructure/postgres"
+	"OnlineLeadership/migrations"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/golang-migrate/migrate/v4"
+	pgmigrate "github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jmoiron/sqlx"
+	"io/fs"
+	"sort"
+	"strings"
+)
+
+// lockID keys the session advisory lock held while migrating, so that replicas
+// started together apply the migrations one after another.
+const lockID int64 = 7_402_615_310
+
+// Migration is one embedded migration.
+type Migration struct {
+	Version uint
+	Name    string
+}
+
+// Status is the schema version of the database next to the embedded migrations.
+type Status struct {
+	Version    uint
+	Dirty      bool
+	Migrations []Migration
+}
+
+// Migrations lists the embedded migrations in version order.
+func Migrations() []Migration {
+	entries, err := fs.ReadDir(migrations.FS, ".")
+	if err != nil {
+		panic(fmt.Sprintf("read embedded migrations: %v", err))
+	}
+	var list []Migration
+	for _, entry := range entries {
+		m, err := source.DefaultParse(entry.Name())
+		if err != nil || m.Direction != source.Up {
+			continue
+		}
+		list = append(list, Migration{Version: m.Version, Name: m.Identifier})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Version < list[j].Version })
+	return list
+}
+
+// Latest is the version of the newest embedded migration, the one the code expects.
+func Latest() uint {
+	list := Migrations()
+	if len(list) == 0 {
+		return 0
+	}
+	return list[len(list)-1].Version
+}
+
+// Migrator applies the embedded migrations with golang-migrate, keeping its
+// schema_migrations bookkeeping compatible with the migrate CLI.
+type Migrator struct {
+	db  *sqlx.DB
+	log *logger.SlogLogger
+}
+
+func NewMigrator(db *sqlx.DB, log *logger.SlogLogger) *Migrator {
+	return &Migrator{db: db, log: log}
+}
+
+// Up applies every pending migration.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.locked(ctx, func(mg *migrate.Migrate) error {
+		return ignoreNoChange(mg.Up())
+	})
+}
+
+// UpSteps applies the next steps pending migrations.
+func (m *Migrator) UpSteps(ctx context.Context, steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("steps must be positive, got %d", steps)
+	}
+	return m.locked(ctx, func(mg *migrate.Migrate) error {
+		return ignoreNoChange(mg.Steps(steps))
+	})
+}
+
+// Down reverts the last steps migrations.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("steps must be positive, got %d", steps)
+	}
+	return m.locked(ctx, func(mg *migrate.Migrate) error {
+		return ignoreNoChange(mg.Steps(-steps))
+	})
+}
+
+// Version returns the current schema version and whether the last migration
+// failed half-way. An unmigrated database is at version 0.
+func (m *Migrator) Version(ctx context.Context) (uint, bool, error) {
+	var version uint
+	var dirty bool
+	err := m.with(ctx, func(mg *migrate.Migrate) error {
+		var err error
+		version, dirty, err = mg.Version()
+		if errors.Is(err, migrate.ErrNilVersion) {
+			return nil
+		}
+		return err
+	})
+	return version, dirty, err
+}
+
+func (m *Migrator) Status(ctx context.Context) (Status, error) {
+	version, dirty, err := m.Version(ctx)
+	if err != nil {
+		return Status{}, err
+	}
+	return Status{Version: version, Dirty: dirty, Migrations: Migrations()}, nil
+}
+
+// locked runs fn while holding the migration advisory lock. The lock is taken
+// on its own connection and waits for as long as ctx allows.
+func (m *Migrator) locked(ctx context.Context, fn func(mg *migrate.Migrate) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get lock connection: %w", err)
+	}
+	defer conn.Close()
+
+	m.log.Info(ctx, "waiting for migration lock")
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockID); err != nil {
+		return fmt.Errorf("failed to take migration lock: %w", err)
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, lockID); err != nil {
+			m.log.Error(ctx, "migration unlock error", "error", err)
+		}
+	}()
+	return m.with(ctx, fn)
+}
+
+// with opens golang-migrate on a dedicated connection and closes it afterwards;
+// the pool itself stays open.
+func (m *Migrator) with(ctx context.Context, fn func(mg *migrate.Migrate) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get migration connection: %w", err)
+	}
+	driver, err := pgmigrate.WithConnection(ctx, conn, &pgmigrate.Config{MigrationsTable: postgres.SchemaMigrations})
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to init migration driver: %w", err)
+	}
+	src, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		driver.Close()
+		return fmt.Errorf("failed to read embedded migrations: %w", err)
+	}
+	mg, err := migrate.NewWithInstance("iofs", src, "postgres", driver)
+	if err != nil {
+		src.Close()
+		driver.Close()
+		return fmt.Errorf("failed to init migrations: %w", err)
+	}
+	defer mg.Close()
+	mg.Log = migrateLog{ctx: ctx, log: m.log}
+	return fn(mg)
+}
+
+func ignoreNoChange(err error) error {
+	if errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+	return err
+}
+
+// migrateLog forwards golang-migrate's progress lines to the service logger.
+type migrateLog struct {
+	ctx context.Context
+	log *logger.SlogLogger
+}
+
+func (l migrateLog) Printf(format string, v ...any) {
+	l.log.Info(l.ctx, strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
+
+func (l migrateLog) Verbose() bool {
+	return false
+}
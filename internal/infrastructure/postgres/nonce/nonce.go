@@ -0,0 +1,34 @@
+package nonce
+
+import (
+	"OnlineLeadership/internal/infrastructure/logger"
+	"context"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Repository remembers the nonces of signed score submissions in Redis.
+type Repository struct {
+	redis *redis.Client
+	log   *logger.SlogLogger
+}
+
+func NewNonceRepository(redis *redis.Client, log *logger.SlogLogger) *Repository {
+	return &Repository{redis: redis, log: log}
+}
+
+func nonceKey(gameID uuid.UUID, nonce string) string {
+	return fmt.Sprintf("score:nonce:%s:%s", gameID, nonce)
+}
+
+// Claim records the nonce for ttl and reports whether it was unused.
+func (r *Repository) Claim(ctx context.Context, gameID uuid.UUID, nonce string, ttl time.Duration) (bool, error) {
+	ok, err := r.redis.SetNX(ctx, nonceKey(gameID, nonce), 1, ttl).Result()
+	if err != nil {
+		r.log.Error(ctx, "repository claim nonce error", err.Error())
+		return false, err
+	}
+	return ok, nil
+}
@@ -0,0 +1,49 @@
+package nonce
+
+import (
+	"OnlineLeadership/internal/infrastructure/logger"
+	"OnlineLeadership/internal/infrastructure/postgres"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"time"
+)
+
+// PostgresRepository remembers the nonces of signed score submissions in the
+// submission_nonces table, for deployments without Redis.
+type PostgresRepository struct {
+	db  *sqlx.DB
+	log *logger.SlogLogger
+}
+
+func NewPostgresNonceRepository(db *sqlx.DB, log *logger.SlogLogger) *PostgresRepository {
+	return &PostgresRepository{db: db, log: log}
+}
+
+// Claim records the nonce for ttl and reports whether it was unused. An
+// expired nonce is claimed again by moving its expiry; the expired rows of
+// other nonces are deleted on the way, as Redis would expire them.
+func (r *PostgresRepository) Claim(ctx context.Context, gameID uuid.UUID, nonce string, ttl time.Duration) (bool, error) {
+	now := time.Now().UTC()
+	query := fmt.Sprintf(`DELETE FROM %s WHERE expires_at <= $1`, postgres.SubmissionNonces)
+	if _, err := r.db.ExecContext(ctx, query, now); err != nil {
+		r.log.Warn(ctx, "repository expire nonces error", "error", err)
+	}
+
+	query = fmt.Sprintf(`
+		INSERT INTO %s AS n (game_id, nonce, expires_at) VALUES ($1, $2, $3)
+		ON CONFLICT (game_id, nonce) DO UPDATE SET expires_at = EXCLUDED.expires_at
+		WHERE n.expires_at <= $4
+	`, postgres.SubmissionNonces)
+	res, err := r.db.ExecContext(ctx, query, gameID, nonce, now.Add(ttl), now)
+	if err != nil {
+		r.log.Error(ctx, "repository claim nonce error", err.Error())
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
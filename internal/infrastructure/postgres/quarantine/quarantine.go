@@ -0,0 +1,117 @@
+package quarantine
+
+import (
+	"OnlineLeadership/internal/domain"
+	"OnlineLeadership/internal/infrastructure/logger"
+	"OnlineLeadership/internal/infrastructure/postgres"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type Repository struct {
+	db  *sqlx.DB
+	log *logger.SlogLogger
+}
+
+func NewQuarantineRepository(db *sqlx.DB, log *logger.SlogLogger) *Repository {
+	return &Repository{db: db, log: log}
+}
+
+func (r *Repository) Save(ctx context.Context, score domain.QuarantinedScore) (uuid.UUID, error) {
+	var id uuid.UUID
+	query := fmt.Sprintf(`INSERT INTO %s (user_id, game_id, score, rule, reason) VALUES ($1, $2, $3, $4, $5) RETURNING id`, postgres.Quarantine)
+	err := r.db.QueryRowContext(ctx, query, score.UserID, score.GameID, score.Score, score.Rule, score.Reason).Scan(&id)
+	if err != nil {
+		r.log.Error(ctx, "repository save quarantined score error", err.Error())
+		return uuid.UUID{}, err
+	}
+	return id, nil
+}
+
+func (r *Repository) Get(ctx context.Context, id uuid.UUID) (domain.QuarantinedScore, error) {
+	var score domain.QuarantinedScore
+	query := fmt.Sprintf(`SELECT id, user_id, game_id, score, rule, reason, status, created_at, score_id FROM %s WHERE id = $1`, postgres.Quarantine)
+	err := r.db.GetContext(ctx, &score, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.QuarantinedScore{}, domain.ErrQuarantineNotFound
+	}
+	return score, err
+}
+
+// List returns quarantined submissions with the given status, oldest first.
+func (r *Repository) List(ctx context.Context, status string, offset int, limit int) ([]domain.QuarantinedScore, error) {
+	scores := []domain.QuarantinedScore{}
+	query := fmt.Sprintf(`
+		SELECT id, user_id, game_id, score, rule, reason, status, created_at, score_id FROM %s
+		WHERE status = $1 ORDER BY created_at, id OFFSET $2 LIMIT $3`, postgres.Quarantine)
+	if err := r.db.SelectContext(ctx, &scores, query, status, offset, limit); err != nil {
+		r.log.Error(ctx, "repository list quarantined scores error", err.Error())
+		return nil, err
+	}
+	return scores, nil
+}
+
+// Resolve moves a submission from the from status to the to status. It fails
+// with domain.ErrQuarantineResolved when the submission is no longer in from,
+// so that concurrent moderators cannot resolve it twice.
+func (r *Repository) Resolve(ctx context.Context, id uuid.UUID, from string, to string) error {
+	query := fmt.Sprintf(`UPDATE %s SET status = $3 WHERE id = $1 AND status = $2`, postgres.Quarantine)
+	res, err := r.db.ExecContext(ctx, query, id, from, to)
+	if err != nil {
+		r.log.Error(ctx, "repository resolve quarantined score error", err.Error())
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		if _, err := r.Get(ctx, id); err != nil {
+			return err
+		}
+		return domain.ErrQuarantineResolved
+	}
+	return nil
+}
+
+// Release moves a pending submission to released and records it in the score
+// history in one transaction, returning the id of the history entry. It fails
+// with domain.ErrQuarantineResolved when the submission is no longer pending,
+// so that a submission is recorded once even if the release is retried.
+func (r *Repository) Release(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	defer tx.Rollback()
+
+	var score domain.QuarantinedScore
+	query := fmt.Sprintf(`SELECT id, user_id, game_id, score, status FROM %s WHERE id = $1 FOR UPDATE`, postgres.Quarantine)
+	err = tx.GetContext(ctx, &score, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return uuid.UUID{}, domain.ErrQuarantineNotFound
+	}
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	if score.Status != domain.QuarantinePending {
+		return uuid.UUID{}, domain.ErrQuarantineResolved
+	}
+
+	scoreID := uuid.New()
+	query = fmt.Sprintf(`INSERT INTO %s (id, user_id, game_id, score) VALUES ($1, $2, $3, $4)`, postgres.ScoreHistory)
+	if _, err := tx.ExecContext(ctx, query, scoreID, score.UserID, score.GameID, score.Score); err != nil {
+		r.log.Error(ctx, "repository save released score error", err.Error())
+		return uuid.UUID{}, err
+	}
+	query = fmt.Sprintf(`UPDATE %s SET status = $2, score_id = $3 WHERE id = $1`, postgres.Quarantine)
+	if _, err := tx.ExecContext(ctx, query, id, domain.QuarantineReleased, scoreID); err != nil {
+		r.log.Error(ctx, "repository release quarantined score error", err.Error())
+		return uuid.UUID{}, err
+	}
+	return scoreID, tx.Commit()
+}
@@ -1,9 +1,15 @@
 package repository
 
 import (
+	"OnlineLeadership/internal/domain"
 	"OnlineLeadership/internal/infrastructure/logger"
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"github.com/jmoiron/sqlx"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -17,19 +23,297 @@ func NewScoreHistoryRepo(db *sqlx.DB, log *logger.SlogLogger) *ScoreHistoryRepo
 	return &ScoreHistoryRepo{db: db, log: log}
 }
 
-func (r *ScoreHistoryRepo) Save(ctx context.Context, userID uuid.UUID, gameID uuid.UUID, score int) error {
+// Save records an accepted score and returns the id of the history entry.
+func (r *ScoreHistoryRepo) Save(ctx context.Context, userID uuid.UUID, gameID uuid.UUID, score int) (uuid.UUID, error) {
 	query := `
 		INSERT INTO score_history (id, user_id, game_id, score) VALUES ($1, $2, $3, $4)
 	`
 
+	id := uuid.New()
 	_, err := r.db.ExecContext(
 		ctx,
 		query,
-		uuid.New(),
+		id,
 		userID,
 		gameID,
 		score,
 	)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	return id, nil
+}
+
+// SaveAdjustment records an admin correction of the user's game score and returns the id of the history entry.
+func (r *ScoreHistoryRepo) SaveAdjustment(ctx context.Context, userID uuid.UUID, gameID uuid.UUID, delta int) (uuid.UUID, error) {
+	query := `
+		INSERT INTO score_history (id, user_id, game_id, score, kind) VALUES ($1, $2, $3, $4, 'adjustment')
+	`
+
+	id := uuid.New()
+	if _, err := r.db.ExecContext(ctx, query, id, userID, gameID, delta); err != nil {
+		r.log.Error(ctx, "repository save adjustment error", err.Error())
+		return uuid.UUID{}, err
+	}
+	return id, nil
+}
+
+// Void marks an accepted score as voided and returns it.
+func (r *ScoreHistoryRepo) Void(ctx context.Context, id uuid.UUID) (domain.ScoreRecord, error) {
+	query := `
+		UPDATE score_history SET status = 'voided' WHERE id = $1 AND status = 'accepted'
+		RETURNING id, user_id, game_id, score, kind, status, created_at
+	`
+
+	var record domain.ScoreRecord
+	err := r.db.GetContext(ctx, &record, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		if _, err := r.Get(ctx, id); err != nil {
+			return domain.ScoreRecord{}, err
+		}
+		return domain.ScoreRecord{}, domain.ErrScoreVoided
+	}
+	if err != nil {
+		r.log.Error(ctx, "repository void score error", err.Error())
+		return domain.ScoreRecord{}, err
+	}
+	return record, nil
+}
+
+// VoidUserScores voids the user's accepted scores for the game, or for every
+// game when gameID is nil, and returns how many were voided.
+func (r *ScoreHistoryRepo) VoidUserScores(ctx context.Context, userID uuid.UUID, gameID *uuid.UUID) (int64, error) {
+	query := `
+		UPDATE score_history SET status = 'voided'
+		WHERE user_id = $1 AND ($2::uuid IS NULL OR game_id = $2) AND status = 'accepted'
+	`
+
+	res, err := r.db.ExecContext(ctx, query, userID, gameID)
+	if err != nil {
+		r.log.Error(ctx, "repository void user scores error", err.Error())
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *ScoreHistoryRepo) Get(ctx context.Context, id uuid.UUID) (domain.ScoreRecord, error) {
+	query := `
+		SELECT id, user_id, game_id, score, kind, status, created_at FROM score_history WHERE id = $1
+	`
+
+	var record domain.ScoreRecord
+	err := r.db.GetContext(ctx, &record, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ScoreRecord{}, domain.ErrScoreNotFound
+	}
+	return record, err
+}
+
+// GameTotal returns the user's accepted score aggregates for the game; ok is
+// false when the user has no accepted entry for it.
+func (r *ScoreHistoryRepo) GameTotal(ctx context.Context, userID uuid.UUID, gameID uuid.UUID) (domain.GameTotal, bool, error) {
+	query := `
+		SELECT game_id, sum(score) AS sum, COALESCE(max(score) FILTER (WHERE kind = 'submission'), 0) AS best
+		FROM score_history WHERE user_id = $1 AND game_id = $2 AND status = 'accepted'
+		GROUP BY game_id
+	`
+
+	var total domain.GameTotal
+	err := r.db.GetContext(ctx, &total, query, userID, gameID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.GameTotal{GameID: gameID}, false, nil
+	}
+	if err != nil {
+		r.log.Error(ctx, "repository game total error", err.Error())
+		return domain.GameTotal{}, false, err
+	}
+	return total, true, nil
+}
+
+// HasGameScores reports whether any accepted score has been recorded for the game.
+func (r *ScoreHistoryRepo) HasGameScores(ctx context.Context, gameID uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS (SELECT 1 FROM score_history WHERE game_id = $1 AND status = 'accepted')
+	`
+
+	var exists bool
+	if err := r.db.GetContext(ctx, &exists, query, gameID); err != nil {
+		r.log.Error(ctx, "repository has game scores error", err.Error())
+		return false, err
+	}
+	return exists, nil
+}
+
+// GetUserGames returns the ids of all games the user has submitted scores for.
+func (r *ScoreHistoryRepo) GetUserGames(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	query := `
+		SELECT DISTINCT game_id FROM score_history WHERE user_id = $1
+	`
+
+	var games []uuid.UUID
+	if err := r.db.SelectContext(ctx, &games, query, userID); err != nil {
+		r.log.Error(ctx, "repository get user games error", err.Error())
+		return nil, err
+	}
+	return games, nil
+}
+
+// UserGameTotals returns the sum of the user's accepted scores and their best
+// accepted submission for every game they played.
+func (r *ScoreHistoryRepo) UserGameTotals(ctx context.Context, userID uuid.UUID) ([]domain.GameTotal, error) {
+	query := `
+		SELECT game_id, sum(score) AS sum, COALESCE(max(score) FILTER (WHERE kind = 'submission'), 0) AS best
+		FROM score_history WHERE user_id = $1 AND status = 'accepted'
+		GROUP BY game_id
+	`
+
+	var totals []domain.GameTotal
+	if err := r.db.SelectContext(ctx, &totals, query, userID); err != nil {
+		r.log.Error(ctx, "repository user game totals error", err.Error())
+		return nil, err
+	}
+	return totals, nil
+}
+
+// GameTotals returns the accepted score aggregates of every player of the game,
+// leaving out players hidden by an active visibility ban.
+func (r *ScoreHistoryRepo) GameTotals(ctx context.Context, gameID uuid.UUID) ([]domain.PlayerTotal, error) {
+	query := `
+		SELECT s.user_id, u.region, sum(s.score) AS sum,
+		       COALESCE(max(s.score) FILTER (WHERE s.kind = 'submission'), 0) AS best
+		FROM score_history s JOIN users u ON u.id = s.user_id
+		WHERE s.game_id = $1 AND s.status = 'accepted'
+		  AND NOT EXISTS (
+		      SELECT 1 FROM bans b
+		      WHERE b.user_id = s.user_id AND $2 = ANY(b.scopes) AND b.lifted_at IS NULL
+		        AND (b.expires_at IS NULL OR b.expires_at > now()))
+		GROUP BY s.user_id, u.region
+	`
+
+	var totals []domain.PlayerTotal
+	if err := r.db.SelectContext(ctx, &totals, query, gameID, domain.BanScopeVisibility); err != nil {
+		r.log.Error(ctx, "repository game totals error", err.Error())
+		return nil, err
+	}
+	return totals, nil
+}
+
+// ListUserScores returns the user's history entries matching the filter, newest
+// first. It fetches one entry beyond the limit to tell whether another page follows.
+func (r *ScoreHistoryRepo) ListUserScores(ctx context.Context, filter domain.ScoreHistoryFilter) ([]domain.ScoreRecord, error) {
+	where, args := userScoresWhere(filter)
+	if filter.Cursor != nil {
+		at, id, err := filter.Cursor.TimeKey()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, at, id)
+		where += fmt.Sprintf(" AND (created_at, id) < ($%d, $%d)", len(args)-1, len(args))
+	}
+	args = append(args, filter.Limit+1)
+	query := fmt.Sprintf(`
+		SELECT id, user_id, game_id, score, kind, status, created_at
+		FROM score_history WHERE %s
+		ORDER BY created_at DESC, id DESC LIMIT $%d
+	`, where, len(args))
+
+	var scores []domain.ScoreRecord
+	if err := r.db.SelectContext(ctx, &scores, query, args...); err != nil {
+		r.log.Error(ctx, "repository list user scores error", err.Error())
+		return nil, err
+	}
+	return scores, nil
+}
+
+// UserScoreSummary aggregates the user's accepted entries matching the filter per game.
+// The cursor and limit of the filter are ignored.
+func (r *ScoreHistoryRepo) UserScoreSummary(ctx context.Context, filter domain.ScoreHistoryFilter) ([]domain.ScoreSummary, error) {
+	where, args := userScoresWhere(filter)
+	query := fmt.Sprintf(`
+		SELECT game_id,
+			COALESCE(max(score) FILTER (WHERE kind = 'submission'), 0) AS best,
+			COALESCE(avg(score) FILTER (WHERE kind = 'submission'), 0) AS average,
+			count(*) FILTER (WHERE kind = 'submission') AS count,
+			sum(score) AS total
+		FROM score_history WHERE %s AND status = 'accepted'
+		GROUP BY game_id ORDER BY game_id
+	`, where)
+
+	var summary []domain.ScoreSummary
+	if err := r.db.SelectContext(ctx, &summary, query, args...); err != nil {
+		r.log.Error(ctx, "repository user score summary error", err.Error())
+		return nil, err
+	}
+	return summary, nil
+}
+
+// userScoresWhere builds the conditions shared by the user score queries; they
+// are served by idx_score_user, or idx_score_created for narrow date ranges.
+func userScoresWhere(filter domain.ScoreHistoryFilter) (string, []any) {
+	conds := []string{"user_id = $1"}
+	args := []any{filter.UserID}
+	if filter.GameID != nil {
+		args = append(args, *filter.GameID)
+		conds = append(conds, fmt.Sprintf("game_id = $%d", len(args)))
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conds = append(conds, fmt.Sprintf("created_at >= $%d", len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conds = append(conds, fmt.Sprintf("created_at < $%d", len(args)))
+	}
+	return strings.Join(conds, " AND "), args
+}
+
+// CountSince returns how many scores the user submitted for the game since the
+// given time, quarantined submissions included. A released submission is
+// already in score_history and is counted there only.
+func (r *ScoreHistoryRepo) CountSince(ctx context.Context, userID uuid.UUID, gameID uuid.UUID, since time.Time) (int, error) {
+	query := `
+		SELECT
+			(SELECT count(*) FROM score_history WHERE user_id = $1 AND game_id = $2 AND created_at >= $3 AND kind = 'submission') +
+			(SELECT count(*) FROM score_quarantine WHERE user_id = $1 AND game_id = $2 AND created_at >= $3 AND status <> 'released')
+	`
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, query, userID, gameID, since); err != nil {
+		r.log.Error(ctx, "repository count submissions error", err.Error())
+		return 0, err
+	}
+	return count, nil
+}
+
+// SumSince returns the sum of the user's accepted scores for the game since the given time.
+func (r *ScoreHistoryRepo) SumSince(ctx context.Context, userID uuid.UUID, gameID uuid.UUID, since time.Time) (int64, error) {
+	query := `
+		SELECT COALESCE(sum(score), 0) FROM score_history
+		WHERE user_id = $1 AND game_id = $2 AND created_at >= $3 AND status = 'accepted' AND kind = 'submission'
+	`
+
+	var sum int64
+	if err := r.db.GetContext(ctx, &sum, query, userID, gameID, since); err != nil {
+		r.log.Error(ctx, "repository sum submissions error", err.Error())
+		return 0, err
+	}
+	return sum, nil
+}
+
+// GameStats summarises the game's most recent submissions, at most sample of them.
+func (r *ScoreHistoryRepo) GameStats(ctx context.Context, gameID uuid.UUID, sample int) (domain.ScoreStats, error) {
+	query := `
+		SELECT count(*) AS count, COALESCE(avg(score), 0) AS mean, COALESCE(stddev_pop(score), 0) AS stddev
+		FROM (
+			SELECT score FROM score_history WHERE game_id = $1 AND status = 'accepted' AND kind = 'submission'
+			ORDER BY created_at DESC LIMIT $2
+		) recent
+	`
 
-	return err
+	var stats domain.ScoreStats
+	if err := r.db.GetContext(ctx, &stats, query, gameID, sample); err != nil {
+		r.log.Error(ctx, "repository game stats error", err.Error())
+		return domain.ScoreStats{}, err
+	}
+	return stats, nil
 }
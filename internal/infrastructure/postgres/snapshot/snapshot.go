@@ -0,0 +1,86 @@
+package snapshot
+
+import (
+	"OnlineLeadership/internal/domain"
+	"OnlineLeadership/internal/infrastructure/logger"
+	"OnlineLeadership/internal/infrastructure/postgres"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"time"
+)
+
+// Repository stores rank snapshots. taken_at is a TIMESTAMP holding UTC, so
+// every time is converted to UTC before it is compared or stored.
+type Repository struct {
+	db  *sqlx.DB
+	log *logger.SlogLogger
+}
+
+func NewSnapshotRepository(db *sqlx.DB, log *logger.SlogLogger) *Repository {
+	return &Repository{db: db, log: log}
+}
+
+// Save stores the ranks of a page of a game board taken at the given time.
+// Snapshots already taken for the same time, e.g. by another replica, are kept.
+func (r *Repository) Save(ctx context.Context, gameID uuid.UUID, takenAt time.Time, users []domain.LeaderboardUser) (int64, error) {
+	if len(users) == 0 {
+		return 0, nil
+	}
+	ids := make([]string, len(users))
+	ranks := make([]int64, len(users))
+	scores := make([]int64, len(users))
+	for i, u := range users {
+		ids[i], ranks[i], scores[i] = u.UserID.String(), u.Rank, u.Score
+	}
+	query := fmt.Sprintf(`
+		INSERT INTO %s (user_id, game_id, taken_at, rank, score)
+		SELECT s.user_id, $1, $2, s.rank, s.score
+		FROM unnest($3::uuid[], $4::bigint[], $5::bigint[]) AS s(user_id, rank, score)
+		JOIN %s u ON u.id = s.user_id
+		ON CONFLICT DO NOTHING
+	`, postgres.Snapshots, postgres.Users)
+	res, err := r.db.ExecContext(ctx, query, gameID, takenAt.UTC(), pq.StringArray(ids), pq.Int64Array(ranks), pq.Int64Array(scores))
+	if err != nil {
+		r.log.Error(ctx, "repository save rank snapshots error", err.Error())
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// Prune deletes the snapshots taken before the given time.
+func (r *Repository) Prune(ctx context.Context, before time.Time) (int64, error) {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE taken_at < $1`, postgres.Snapshots)
+	res, err := r.db.ExecContext(ctx, query, before.UTC())
+	if err != nil {
+		r.log.Error(ctx, "repository prune rank snapshots error", err.Error())
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// Series returns the player's snapshots grouped into buckets, oldest first.
+func (r *Repository) Series(ctx context.Context, q domain.ProgressQuery) ([]domain.ProgressPoint, error) {
+	query := fmt.Sprintf(`
+		SELECT date_trunc($1, taken_at) AS at,
+			min(rank) AS best_rank,
+			(array_agg(rank ORDER BY taken_at DESC))[1] AS rank,
+			max(score) AS best_score,
+			(array_agg(score ORDER BY taken_at DESC))[1] AS score
+		FROM %s
+		WHERE user_id = $2 AND game_id = $3 AND taken_at >= $4 AND taken_at < $5
+		GROUP BY 1 ORDER BY 1
+	`, postgres.Snapshots)
+
+	var points []domain.ProgressPoint
+	if err := r.db.SelectContext(ctx, &points, query, q.Bucket, q.UserID, q.GameID, q.From.UTC(), q.To.UTC()); err != nil {
+		r.log.Error(ctx, "repository rank snapshot series error", err.Error())
+		return nil, err
+	}
+	for i := range points {
+		points[i].At = points[i].At.UTC()
+	}
+	return points, nil
+}
@@ -5,6 +5,8 @@ import (
 	"OnlineLeadership/internal/infrastructure/logger"
 	"OnlineLeadership/internal/infrastructure/postgres"
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -24,8 +26,8 @@ func NewAuthRepository(db *sqlx.DB, log *logger.SlogLogger) *Auth {
 
 func (r *Auth) CreateUser(ctx context.Context, user domain.User) (uuid.UUID, error) {
 	var id uuid.UUID
-	query := fmt.Sprintf("INSERT INTO %s (username,password_hash,email) values ($1,$2,$3) RETURNING id", postgres.Users)
-	row := r.db.QueryRow(query, user.Username, user.Password, user.Email)
+	query := fmt.Sprintf("INSERT INTO %s (username,password_hash,email,region) values ($1,$2,$3,$4) RETURNING id", postgres.Users)
+	row := r.db.QueryRow(query, user.Username, user.Password, user.Email, user.Region)
 	if err := row.Scan(&id); err != nil {
 		r.log.Error(ctx, "creating user error ", err.Error())
 		return uuid.UUID{}, err
@@ -52,3 +54,51 @@ func (r *Auth) GetUserByUsername(ctx context.Context, username string) (domain.U
 	}
 	return user, err
 }
+
+func (r *Auth) GetUserByID(ctx context.Context, userID uuid.UUID) (domain.User, error) {
+	var user domain.User
+	query := fmt.Sprintf("SELECT id, username, email, region, role FROM %s WHERE id=$1", postgres.Users)
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&user.Id, &user.Username, &user.Email, &user.Region, &user.Role)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.User{}, domain.ErrUserNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "postgres get user by id error", err.Error())
+	}
+	return user, err
+}
+
+// SetRole changes the user's role and returns the previous one.
+func (r *Auth) SetRole(ctx context.Context, userID uuid.UUID, role string) (string, error) {
+	var previous string
+	query := fmt.Sprintf(`
+		UPDATE %[1]s u SET role = $2
+		FROM (SELECT id, role FROM %[1]s WHERE id = $1 FOR UPDATE) old
+		WHERE u.id = old.id
+		RETURNING old.role`, postgres.Users)
+	err := r.db.QueryRowContext(ctx, query, userID, role).Scan(&previous)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", domain.ErrUserNotFound
+	}
+	if err != nil {
+		r.log.Error(ctx, "postgres set role error", err.Error())
+		return "", err
+	}
+	return previous, nil
+}
+
+// UpdateRegion sets the user's region and returns the region it replaced.
+func (r *Auth) UpdateRegion(ctx context.Context, userID uuid.UUID, region string) (string, error) {
+	var previous string
+	query := fmt.Sprintf(`
+		UPDATE %[1]s u SET region = $2
+		FROM (SELECT id, region FROM %[1]s WHERE id = $1 FOR UPDATE) old
+		WHERE u.id = old.id
+		RETURNING old.region`, postgres.Users)
+	err := r.db.QueryRowContext(ctx, query, userID, region).Scan(&previous)
+	if err != nil {
+		r.log.Error(ctx, "postgres update region error", err.Error())
+		return "", err
+	}
+	return previous, nil
+}
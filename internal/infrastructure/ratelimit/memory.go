@@ -0,0 +1,79 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepEvery is the number of requests between two removals of idle keys.
+const sweepEvery = 1024
+
+// MemoryStore keeps the windows in the process. Each replica counts on its
+// own, so it only fits a single instance or development.
+type MemoryStore struct {
+	mu      sync.Mutex
+	windows map[string]*window
+	calls   int
+}
+
+type window struct {
+	// hits holds the times of the allowed requests, oldest first.
+	hits   []time.Time
+	length time.Duration
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{windows: make(map[string]*window)}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, policy Policy) (Decision, error) {
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls++
+	if s.calls%sweepEvery == 0 {
+		s.sweep(now)
+	}
+	w, ok := s.windows[key]
+	if !ok {
+		w = &window{}
+		s.windows[key] = w
+	}
+	w.length = policy.Window
+	w.expire(now)
+
+	allowed := len(w.hits) < policy.Requests
+	if allowed {
+		w.hits = append(w.hits, now)
+	}
+	decision := Decision{
+		Allowed:   allowed,
+		Limit:     policy.Requests,
+		Remaining: max(policy.Requests-len(w.hits), 0),
+	}
+	if len(w.hits) > 0 {
+		decision.Reset = w.hits[0].Add(w.length).Sub(now)
+	}
+	return decision, nil
+}
+
+// expire drops the hits that left the window.
+func (w *window) expire(now time.Time) {
+	cutoff := now.Add(-w.length)
+	i := 0
+	for i < len(w.hits) && !w.hits[i].After(cutoff) {
+		i++
+	}
+	w.hits = w.hits[i:]
+}
+
+// sweep removes the keys without a request in their window.
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, w := range s.windows {
+		if w.expire(now); len(w.hits) == 0 {
+			delete(s.windows, key)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestMemoryStoreWindow(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	policy := Policy{Requests: 3, Window: 50 * time.Millisecond}
+
+	tests := []struct {
+		key       string
+		allowed   bool
+		remaining int
+	}{
+		{"a", true, 2},
+		{"a", true, 1},
+		{"a", true, 0},
+		{"a", false, 0},
+		// Keys have windows of their own.
+		{"b", true, 2},
+	}
+	for i, tt := range tests {
+		d, err := store.Take(ctx, tt.key, policy)
+		if err != nil {
+			t.Fatalf("Take: %v", err)
+		}
+		if d.Allowed != tt.allowed || d.Remaining != tt.remaining || d.Limit != policy.Requests {
+			t.Fatalf("request %d to %s = %+v, want allowed %v, remaining %d", i, tt.key, d, tt.allowed, tt.remaining)
+		}
+		if d.Reset <= 0 || d.Reset > policy.Window {
+			t.Fatalf("request %d to %s reset = %s, want within (0, %s]", i, tt.key, d.Reset, policy.Window)
+		}
+	}
+
+	// The window slides: once the first requests leave it, the key has room again.
+	time.Sleep(policy.Window + 10*time.Millisecond)
+	d, err := store.Take(ctx, "a", policy)
+	if err != nil || !d.Allowed || d.Remaining != 2 {
+		t.Fatalf("request after the window = %+v, %v; want allowed with 2 remaining", d, err)
+	}
+}
+
+func TestMemoryStoreSweep(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	short := Policy{Requests: 1, Window: time.Millisecond}
+	for i := range sweepEvery - 1 {
+		if _, err := store.Take(ctx, fmt.Sprintf("idle-%d", i), short); err != nil {
+			t.Fatalf("Take: %v", err)
+		}
+	}
+	time.Sleep(5 * time.Millisecond)
+	if _, err := store.Take(ctx, "active", Policy{Requests: 1, Window: time.Minute}); err != nil {
+		t.Fatalf("Take: %v", err)
+	}
+	if len(store.windows) != 1 {
+		t.Fatalf("%d windows after the sweep, want only the active one", len(store.windows))
+	}
+}
+
+func TestLimiterPolicy(t *testing.T) {
+	def := Policy{Requests: 100, Window: time.Minute}
+	login := Policy{Requests: 10, Window: time.Minute}
+	limiter := New(NewMemoryStore(), def, map[string]Policy{"/auth/login": login})
+
+	if got := limiter.Policy("/auth/login"); got != login {
+		t.Errorf("Policy(/auth/login) = %+v, want %+v", got, login)
+	}
+	if got := limiter.Policy("/api/leaderboard"); got != def {
+		t.Errorf("Policy(/api/leaderboard) = %+v, want %+v", got, def)
+	}
+	// Routes count separately for the same client.
+	for range login.Requests {
+		if _, err := limiter.Allow(context.Background(), "/auth/login", "ip:1"); err != nil {
+			t.Fatalf("Allow: %v", err)
+		}
+	}
+	if d, _ := limiter.Allow(context.Background(), "/auth/login", "ip:1"); d.Allowed {
+		t.Error("request over the route limit allowed")
+	}
+	if d, _ := limiter.Allow(context.Background(), "/auth/register", "ip:1"); !d.Allowed {
+		t.Error("request to another route denied")
+	}
+}
@@ -0,0 +1,61 @@
+// Package ratelimit limits how often a client may call a route. Limits are
+// sliding windows: a request is allowed when fewer than Requests requests of
+// the same client to the same route were allowed within the last Window.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrLimited is returned to clients that exceeded the policy of a route.
+var ErrLimited = errors.New("rate limit exceeded")
+
+// Policy allows Requests per Window.
+type Policy struct {
+	Requests int
+	Window   time.Duration
+}
+
+// Decision is the outcome of a request against a policy.
+type Decision struct {
+	Allowed bool
+	Limit   int
+	// Remaining is the number of requests still allowed in the current window.
+	Remaining int
+	// Reset is the time until the oldest request counted leaves the window,
+	// freeing a slot. A denied request may be retried after Reset.
+	Reset time.Duration
+}
+
+// Store records requests per key.
+type Store interface {
+	Take(ctx context.Context, key string, policy Policy) (Decision, error)
+}
+
+// Limiter applies the route policies to clients.
+type Limiter struct {
+	store  Store
+	policy Policy
+	routes map[string]Policy
+}
+
+// New returns a limiter applying policy to every route without its own entry in routes.
+func New(store Store, policy Policy, routes map[string]Policy) *Limiter {
+	return &Limiter{store: store, policy: policy, routes: routes}
+}
+
+// Policy returns the policy of the route.
+func (l *Limiter) Policy(route string) Policy {
+	if policy, ok := l.routes[route]; ok {
+		return policy
+	}
+	return l.policy
+}
+
+// Allow counts a request of client to route. Each route has its own window,
+// so heavy use of one route does not exhaust the others.
+func (l *Limiter) Allow(ctx context.Context, route string, client string) (Decision, error) {
+	return l.store.Take(ctx, "ratelimit:"+route+":"+client, l.Policy(route))
+}
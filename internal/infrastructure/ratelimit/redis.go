@@ -0,0 +1,65 @@
+package ratelimit
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/go-redis/redis/v8"
+	"strconv"
+	"time"
+)
+
+// slidingWindowScript keeps the times of the allowed requests of a key in a
+// sorted set. It drops the entries older than the window, records the request
+// if the window has room and returns whether it did, the number of requests
+// in the window and the milliseconds until the oldest of them expires.
+var slidingWindowScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+local count = redis.call('ZCARD', KEYS[1])
+local allowed = 0
+if count < limit then
+	redis.call('ZADD', KEYS[1], now, ARGV[4])
+	count = count + 1
+	allowed = 1
+end
+redis.call('PEXPIRE', KEYS[1], window)
+local reset = 0
+local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+if oldest[2] then
+	reset = tonumber(oldest[2]) + window - now
+end
+return {allowed, count, reset}
+`)
+
+// RedisStore shares the windows between every replica using the same Redis.
+type RedisStore struct {
+	rdb *redis.Client
+}
+
+func NewRedisStore(rdb *redis.Client) *RedisStore {
+	return &RedisStore{rdb: rdb}
+}
+
+func (s *RedisStore) Take(ctx context.Context, key string, policy Policy) (Decision, error) {
+	now := time.Now().UnixMilli()
+	// Requests made in the same millisecond need distinct members.
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return Decision{}, err
+	}
+	member := strconv.FormatInt(now, 10) + "-" + hex.EncodeToString(suffix)
+
+	res, err := slidingWindowScript.Run(ctx, s.rdb, []string{key}, now, policy.Window.Milliseconds(), policy.Requests, member).Int64Slice()
+	if err != nil {
+		return Decision{}, err
+	}
+	return Decision{
+		Allowed:   res[0] == 1,
+		Limit:     policy.Requests,
+		Remaining: max(policy.Requests-int(res[1]), 0),
+		Reset:     time.Duration(res[2]) * time.Millisecond,
+	}, nil
+}
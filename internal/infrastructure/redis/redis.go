@@ -1,18 +1,18 @@
 package redis
 
 import (
+	"OnlineLeadership/internal/infrastructure/metrics"
+	"OnlineLeadership/internal/infrastructure/tracing"
 	"github.com/go-redis/redis/v8"
-	"os"
 )
 
-func InitRedis() *redis.Client {
-	addr := os.Getenv("REDIS_ADDR")
-	if addr == "" {
-		addr = "127.0.0.1:6379" // fallback для локалки
-	}
-
-	return redis.NewClient(&redis.Options{
-		Addr: addr,
-		DB:   0,
+func InitRedis(addr, password string, db int) *redis.Client {
+	client := redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Password: password,
+		DB:       db,
 	})
+	client.AddHook(metrics.RedisHook{})
+	client.AddHook(tracing.RedisHook{})
+	return client
 }
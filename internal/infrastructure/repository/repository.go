@@ -1,50 +1,199 @@
 package repository
 
 import (
+	"OnlineLeadership/config"
 	"OnlineLeadership/internal/domain"
 	"OnlineLeadership/internal/infrastructure/logger"
 	"OnlineLeadership/internal/infrastructure/postgres/admin"
+	"OnlineLeadership/internal/infrastructure/postgres/audit"
+	"OnlineLeadership/internal/infrastructure/postgres/ban"
+	"OnlineLeadership/internal/infrastructure/postgres/flag"
+	"OnlineLeadership/internal/infrastructure/postgres/health"
 	leader "OnlineLeadership/internal/infrastructure/postgres/leaderboard"
+	"OnlineLeadership/internal/infrastructure/postgres/nonce"
+	"OnlineLeadership/internal/infrastructure/postgres/quarantine"
 	score "OnlineLeadership/internal/infrastructure/postgres/score_history"
+	"OnlineLeadership/internal/infrastructure/postgres/snapshot"
 	"OnlineLeadership/internal/infrastructure/postgres/user"
 	"context"
 	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"time"
 )
 
 type Auth interface {
 	CreateUser(ctx context.Context, user domain.User) (uuid.UUID, error)
 	GetUser(ctx context.Context, username, password string) (domain.User, error)
 	GetUserByUsername(ctx context.Context, username string) (domain.User, error)
+	GetUserByID(ctx context.Context, userID uuid.UUID) (domain.User, error)
+	UpdateRegion(ctx context.Context, userID uuid.UUID, region string) (string, error)
+	SetRole(ctx context.Context, userID uuid.UUID, role string) (string, error)
 }
 type ScoreHistory interface {
-	Save(ctx context.Context, userID uuid.UUID, gameID uuid.UUID, score int) error
+	Save(ctx context.Context, userID uuid.UUID, gameID uuid.UUID, score int) (uuid.UUID, error)
+	SaveAdjustment(ctx context.Context, userID uuid.UUID, gameID uuid.UUID, delta int) (uuid.UUID, error)
+	Get(ctx context.Context, id uuid.UUID) (domain.ScoreRecord, error)
+	Void(ctx context.Context, id uuid.UUID) (domain.ScoreRecord, error)
+	VoidUserScores(ctx context.Context, userID uuid.UUID, gameID *uuid.UUID) (int64, error)
+	UserGameTotals(ctx context.Context, userID uuid.UUID) ([]domain.GameTotal, error)
+	GameTotals(ctx context.Context, gameID uuid.UUID) ([]domain.PlayerTotal, error)
+	GameTotal(ctx context.Context, userID uuid.UUID, gameID uuid.UUID) (domain.GameTotal, bool, error)
+	HasGameScores(ctx context.Context, gameID uuid.UUID) (bool, error)
+	GetUserGames(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	ListUserScores(ctx context.Context, filter domain.ScoreHistoryFilter) ([]domain.ScoreRecord, error)
+	UserScoreSummary(ctx context.Context, filter domain.ScoreHistoryFilter) ([]domain.ScoreSummary, error)
+	CountSince(ctx context.Context, userID uuid.UUID, gameID uuid.UUID, since time.Time) (int, error)
+	SumSince(ctx context.Context, userID uuid.UUID, gameID uuid.UUID, since time.Time) (int64, error)
+	GameStats(ctx context.Context, gameID uuid.UUID, sample int) (domain.ScoreStats, error)
 }
+type Quarantine interface {
+	Save(ctx context.Context, score domain.QuarantinedScore) (uuid.UUID, error)
+	Get(ctx context.Context, id uuid.UUID) (domain.QuarantinedScore, error)
+	List(ctx context.Context, status string, offset int, limit int) ([]domain.QuarantinedScore, error)
+	Resolve(ctx context.Context, id uuid.UUID, from string, to string) error
+	Release(ctx context.Context, id uuid.UUID) (uuid.UUID, error)
+}
+type Flags interface {
+	Create(ctx context.Context, flag domain.ScoreFlag) (uuid.UUID, error)
+	Get(ctx context.Context, id uuid.UUID) (domain.ScoreFlag, error)
+	List(ctx context.Context, status string, offset int, limit int) ([]domain.ScoreFlag, error)
+	Decide(ctx context.Context, id uuid.UUID, decision string, moderatorID uuid.UUID) (domain.ScoreRecord, bool, error)
+}
+
+// LeaderBoard is the ranking store behind every board: the global board, one
+// board per game, and a regional variant of each that holds the same scores
+// for the players of one region. Implementations must agree on:
+//
+//   - order: highest score first, ties ordered by user id in reverse
+//     lexicographical order, so that ranks are ordinal and stable;
+//   - ranks: 1-based, -1 for a player who is not on the board;
+//   - region: "" addresses the overall board, any other value its regional
+//     variant, and writes with a region update both;
+//   - pages: an offset page and a cursor page return the same rows, and a
+//     cursor is only returned when more rows follow;
+//   - versions: every write to a game board bumps its version, except a
+//     SetBestGameScore that keeps the current score.
+//
+// The game board writes leave the global boards alone: the caller applies the
+// change they return with IncrementGlobalScore. RemoveMember and PurgeGame are
+// the exception and keep the global boards consistent themselves.
 type LeaderBoard interface {
-	IncrementGameScore(ctx context.Context, gameID string, userID string, score int) error
-	IncrementGlobalScore(ctx context.Context, userID string, score int) error
-	GetGlobal(ctx context.Context, offset int, limit int) ([]domain.LeaderboardUser, error)
-	GetMyRank(ctx context.Context, userID uuid.UUID) (int64, error)
-	GetLeaderboard(ctx context.Context, gameID uuid.UUID) ([]domain.LeaderboardUser, error)
+	// IncrementGameScore adds score to the player's game score.
+	IncrementGameScore(ctx context.Context, gameID string, userID string, region string, score int) error
+	// SetBestGameScore keeps the higher of score and the current game score and returns the increase.
+	SetBestGameScore(ctx context.Context, gameID string, userID string, region string, score int) (int64, error)
+	// ReplaceGameScore sets the game score, or removes the player when keep is false, and returns the change.
+	ReplaceGameScore(ctx context.Context, gameID string, userID string, region string, score int64, keep bool) (int64, error)
+	// IncrementGlobalScore adds score to the player's global score, adding the player if needed.
+	IncrementGlobalScore(ctx context.Context, userID string, region string, score int) error
+	// SetGlobalScore overwrites the player's global score.
+	SetGlobalScore(ctx context.Context, userID string, region string, score int64) error
+	GetGlobal(ctx context.Context, region string, page domain.PageRequest) (domain.LeaderboardPage, error)
+	GetMyRank(ctx context.Context, userID uuid.UUID, region string) (domain.RankStats, error)
+	GetGameRank(ctx context.Context, gameID uuid.UUID, userID uuid.UUID, region string) (domain.RankStats, error)
+	// GetDistribution splits the score range of a game board into equal buckets;
+	// implementations may serve it from a short-lived cache.
+	GetDistribution(ctx context.Context, gameID uuid.UUID, region string, buckets int) (domain.ScoreDistribution, error)
+	GetLeaderboard(ctx context.Context, gameID uuid.UUID, region string, page domain.PageRequest) (domain.LeaderboardPage, error)
+	// GetBoardVersion returns version 0 for a board that was never written to.
+	GetBoardVersion(ctx context.Context, gameID uuid.UUID) (domain.BoardVersion, error)
+	// BoardSizes counts the players on the global board and the game boards, keyed by "global" and game id.
+	BoardSizes(ctx context.Context, gameIDs []uuid.UUID) (map[string]int64, error)
+	// RemoveMember takes the player off the game boards, subtracting their game
+	// scores from the global boards, and off the global boards too with global set.
+	RemoveMember(ctx context.Context, gameIDs []uuid.UUID, userID string, region string, global bool) error
+	// MoveRegion copies the player's overall scores into the boards of region to
+	// and removes them from the boards of region from.
+	MoveRegion(ctx context.Context, userID uuid.UUID, gameIDs []uuid.UUID, from string, to string) error
+	// PurgeGame subtracts the game's scores from the global boards, keeping the
+	// players on them even at zero, and deletes its boards. It can be retried after a failure.
+	PurgeGame(ctx context.Context, gameID uuid.UUID) error
 }
 type Admin interface {
-	Create(ctx context.Context, name string) (uuid.UUID, error)
+	Create(ctx context.Context, game domain.Game) (uuid.UUID, error)
 	GetGames(ctx context.Context) ([]domain.Game, error)
+	GetGame(ctx context.Context, id uuid.UUID) (domain.Game, error)
+	UpdateGame(ctx context.Context, game domain.Game) error
+	SetArchived(ctx context.Context, id uuid.UUID, archived bool) error
+	SetSigningSecret(ctx context.Context, id uuid.UUID, secret string) error
+	DeleteGame(ctx context.Context, id uuid.UUID) error
+}
+type Nonces interface {
+	Claim(ctx context.Context, gameID uuid.UUID, nonce string, ttl time.Duration) (bool, error)
+}
+type Audit interface {
+	Record(ctx context.Context, entry domain.AuditEntry) error
+	List(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error)
+}
+type Snapshots interface {
+	Save(ctx context.Context, gameID uuid.UUID, takenAt time.Time, users []domain.LeaderboardUser) (int64, error)
+	Prune(ctx context.Context, before time.Time) (int64, error)
+	Series(ctx context.Context, q domain.ProgressQuery) ([]domain.ProgressPoint, error)
+}
+type Bans interface {
+	Create(ctx context.Context, ban domain.Ban) (uuid.UUID, error)
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]domain.Ban, error)
+	ListActive(ctx context.Context) ([]domain.Ban, error)
+	ListExpired(ctx context.Context) ([]domain.Ban, error)
+	Lift(ctx context.Context, id uuid.UUID, liftedBy *uuid.UUID, at time.Time) (domain.Ban, error)
+}
+type Health interface {
+	PingPostgres(ctx context.Context) error
+	PingRedis(ctx context.Context) error
+	// RedisConfigured reports whether the service was started with Redis.
+	RedisConfigured() bool
+	SchemaVersion(ctx context.Context) (uint, bool, error)
 }
+
 type Repository struct {
 	Auth
 	ScoreHistory
 	LeaderBoard
 	Admin
+	Quarantine
+	Nonces
+	Flags
+	Audit
+	Bans
+	Snapshots
+	Health
 }
 
-func NewRepository(db *sqlx.DB, redis *redis.Client, log *logger.SlogLogger) *Repository {
+// NewRepository wires the stores. redis may be nil when no configured store
+// needs it; the submission nonces are then kept in Postgres.
+func NewRepository(db *sqlx.DB, redis *redis.Client, leaderboard string, log *logger.SlogLogger) *Repository {
 	return &Repository{
 		Auth:         user.NewAuthRepository(db, log),
 		ScoreHistory: score.NewScoreHistoryRepo(db, log),
-		LeaderBoard:  leader.NewLeaderboardRepo(db, redis, log),
+		LeaderBoard:  newLeaderBoard(leaderboard, db, redis, log),
 		Admin:        admin.NewAdminRepository(db, log),
+		Quarantine:   quarantine.NewQuarantineRepository(db, log),
+		Nonces:       newNonces(db, redis, log),
+		Flags:        flag.NewFlagRepository(db, log),
+		Audit:        audit.NewAuditRepository(db, log),
+		Bans:         ban.NewBanRepository(db, log),
+		Snapshots:    snapshot.NewSnapshotRepository(db, log),
+		Health:       health.NewHealthRepository(db, redis, log),
 	}
 
 }
+
+// newNonces keeps the submission nonces in Redis when there is one.
+func newNonces(db *sqlx.DB, redis *redis.Client, log *logger.SlogLogger) Nonces {
+	if redis == nil {
+		return nonce.NewPostgresNonceRepository(db, log)
+	}
+	return nonce.NewNonceRepository(redis, log)
+}
+
+// newLeaderBoard returns the leaderboard store named by store, Redis by default.
+func newLeaderBoard(store string, db *sqlx.DB, redis *redis.Client, log *logger.SlogLogger) LeaderBoard {
+	switch store {
+	case config.LeaderboardMemory:
+		return leader.NewMemoryLeaderboard()
+	case config.LeaderboardPostgres:
+		return leader.NewPostgresLeaderboard(db, log)
+	}
+	return leader.NewLeaderboardRepo(db, redis, log)
+}
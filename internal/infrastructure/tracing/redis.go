@@ -0,0 +1,66 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"github.com/go-redis/redis/v8"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentation = "OnlineLeadership/internal/infrastructure/tracing"
+
+// RedisHook wraps every Redis command and pipeline in a client span. Command
+// arguments are not recorded since they carry user IDs and nonces.
+type RedisHook struct{}
+
+var _ redis.Hook = RedisHook{}
+
+func (RedisHook) BeforeProcess(ctx context.Context, cmd redis.Cmder) (context.Context, error) {
+	return startRedis(ctx, cmd.FullName(), attribute.String("db.operation.name", cmd.Name())), nil
+}
+
+func (RedisHook) AfterProcess(ctx context.Context, cmd redis.Cmder) error {
+	endRedis(ctx, cmd.Err())
+	return nil
+}
+
+func (RedisHook) BeforeProcessPipeline(ctx context.Context, cmds []redis.Cmder) (context.Context, error) {
+	return startRedis(ctx, "pipeline",
+		attribute.String("db.operation.name", "pipeline"),
+		attribute.Int("db.operation.batch.size", len(cmds)),
+	), nil
+}
+
+func (RedisHook) AfterProcessPipeline(ctx context.Context, cmds []redis.Cmder) error {
+	var err error
+	for _, cmd := range cmds {
+		if cerr := cmd.Err(); cerr != nil && !errors.Is(cerr, redis.Nil) {
+			err = cerr
+			break
+		}
+	}
+	endRedis(ctx, err)
+	return nil
+}
+
+func startRedis(ctx context.Context, name string, attrs ...attribute.KeyValue) context.Context {
+	ctx, _ = otel.Tracer(instrumentation).Start(ctx, "redis "+name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemNameRedis),
+		trace.WithAttributes(attrs...),
+	)
+	return ctx
+}
+
+func endRedis(ctx context.Context, err error) {
+	span := trace.SpanFromContext(ctx)
+	if err != nil && !errors.Is(err, redis.Nil) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	leaderboardv1 "OnlineLeadership/api/proto/leaderboard/v1"
+	"OnlineLeadership/internal/domain"
+	"OnlineLeadership/internal/infrastructure/logger"
+	"OnlineLeadership/internal/usecase"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"strings"
+)
+
+const authorizationMetadata = "authorization"
+
+// publicMethods may be called without an access token.
+var publicMethods = map[string]bool{
+	leaderboardv1.AuthService_Register_FullMethodName: true,
+	leaderboardv1.AuthService_Login_FullMethodName:    true,
+}
+
+type userKey struct{}
+
+// authenticatedUser returns the id of the user authenticated by the interceptor.
+func authenticatedUser(ctx context.Context) (uuid.UUID, error) {
+	id, ok := ctx.Value(userKey{}).(uuid.UUID)
+	if !ok {
+		return uuid.UUID{}, status.Error(codes.Unauthenticated, "user not authorized")
+	}
+	return id, nil
+}
+
+// authenticator checks the "authorization: Bearer <token>" metadata of every
+// call but those to publicMethods, as userIdentity does for the HTTP API.
+type authenticator struct {
+	service *usecase.Service
+}
+
+func (a *authenticator) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if publicMethods[method] {
+		return ctx, nil
+	}
+	values := metadata.ValueFromIncomingContext(ctx, authorizationMetadata)
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "empty auth metadata")
+	}
+	token, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok || token == "" {
+		return nil, status.Error(codes.Unauthenticated, "invalid auth metadata")
+	}
+	id, err := a.service.Auth.ParseAccessToken(ctx, token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	// Tokens issued before a login ban stop working as soon as it is in force.
+	if err := a.service.Bans.CheckBan(ctx, id, domain.BanScopeLogin); err != nil {
+		return nil, toStatus(err)
+	}
+	return context.WithValue(ctx, userKey{}, id), nil
+}
+
+func (a *authenticator) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := a.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *authenticator) stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+}
+
+// contextStream replaces the context of a stream, e.g. to carry the authenticated user.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+type authServer struct {
+	leaderboardv1.UnimplementedAuthServiceServer
+	service *usecase.Service
+	log     *logger.SlogLogger
+}
+
+func (s *authServer) Register(ctx context.Context, req *leaderboardv1.RegisterRequest) (*leaderboardv1.RegisterResponse, error) {
+	if req.GetUsername() == "" || req.GetEmail() == "" || len(req.GetPassword()) < 6 {
+		return nil, status.Error(codes.InvalidArgument, "username, email and a password of at least 6 characters are required")
+	}
+	id, err := s.service.Auth.Register(ctx, domain.User{
+		Username: req.GetUsername(),
+		Email:    req.GetEmail(),
+		Password: req.GetPassword(),
+		Region:   req.GetRegion(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &leaderboardv1.RegisterResponse{UserId: id.String()}, nil
+}
+
+func (s *authServer) Login(ctx context.Context, req *leaderboardv1.LoginRequest) (*leaderboardv1.LoginResponse, error) {
+	access, refresh, err := s.service.Auth.Login(ctx, req.GetUsername(), req.GetPassword())
+	if errors.Is(err, sql.ErrNoRows) || errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return nil, status.Error(codes.Unauthenticated, "invalid username or password")
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &leaderboardv1.LoginResponse{AccessToken: access, RefreshToken: refresh}, nil
+}
@@ -0,0 +1,171 @@
+package server
+
+import (
+	leaderboardv1 "OnlineLeadership/api/proto/leaderboard/v1"
+	"OnlineLeadership/internal/domain"
+	"OnlineLeadership/internal/infrastructure/logger"
+	"OnlineLeadership/internal/usecase"
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"time"
+)
+
+// DefaultWatchInterval is how often WatchRank checks the rank unless the client asks otherwise.
+const DefaultWatchInterval = 5 * time.Second
+
+type leaderboardServer struct {
+	leaderboardv1.UnimplementedLeaderboardServiceServer
+	service *usecase.Service
+	log     *logger.SlogLogger
+}
+
+func (s *leaderboardServer) GetGlobalLeaderboard(ctx context.Context, req *leaderboardv1.GetGlobalLeaderboardRequest) (*leaderboardv1.LeaderboardPage, error) {
+	page, err := pageRequest(req.GetOffset(), req.GetLimit(), req.GetCursor())
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.service.Leaderboard.GetGlobalLeaderboard(ctx, req.GetRegion(), page)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return newLeaderboardPage(res), nil
+}
+
+func (s *leaderboardServer) GetGameLeaderboard(ctx context.Context, req *leaderboardv1.GetGameLeaderboardRequest) (*leaderboardv1.LeaderboardPage, error) {
+	gameID, err := parseGameID(req.GetGameId())
+	if err != nil {
+		return nil, err
+	}
+	page, err := pageRequest(req.GetOffset(), req.GetLimit(), req.GetCursor())
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.service.Leaderboard.GetLeaderboard(ctx, gameID, req.GetRegion(), page)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return newLeaderboardPage(res), nil
+}
+
+func (s *leaderboardServer) GetMyRank(ctx context.Context, _ *leaderboardv1.GetMyRankRequest) (*leaderboardv1.PlayerRank, error) {
+	return s.rank(ctx, uuid.Nil)
+}
+
+func (s *leaderboardServer) GetGameRank(ctx context.Context, req *leaderboardv1.GetGameRankRequest) (*leaderboardv1.PlayerRank, error) {
+	gameID, err := parseGameID(req.GetGameId())
+	if err != nil {
+		return nil, err
+	}
+	return s.rank(ctx, gameID)
+}
+
+// WatchRank polls the caller's rank and sends it whenever it differs from the
+// last one sent. There is no change feed for ranks: a rank also moves when
+// other players overtake the caller.
+func (s *leaderboardServer) WatchRank(req *leaderboardv1.WatchRankRequest, stream grpc.ServerStreamingServer[leaderboardv1.PlayerRank]) error {
+	gameID := uuid.Nil
+	if req.GetGameId() != "" {
+		id, err := parseGameID(req.GetGameId())
+		if err != nil {
+			return err
+		}
+		gameID = id
+	}
+	interval := DefaultWatchInterval
+	if req.GetIntervalSeconds() > 0 {
+		interval = time.Duration(req.GetIntervalSeconds()) * time.Second
+	}
+
+	ctx := stream.Context()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var last *leaderboardv1.PlayerRank
+	for {
+		rank, err := s.rank(ctx, gameID)
+		if err != nil {
+			return err
+		}
+		if !proto.Equal(rank, last) {
+			if err := stream.Send(rank); err != nil {
+				return err
+			}
+			last = rank
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// rank returns the caller's rank on the game's board, or on the global board for uuid.Nil.
+func (s *leaderboardServer) rank(ctx context.Context, gameID uuid.UUID) (*leaderboardv1.PlayerRank, error) {
+	userID, err := authenticatedUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var rank domain.PlayerRank
+	if gameID == uuid.Nil {
+		rank, err = s.service.Leaderboard.GetMyRank(ctx, userID)
+	} else {
+		rank, err = s.service.Leaderboard.GetGameRank(ctx, userID, gameID)
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &leaderboardv1.PlayerRank{
+		Overall:  newRankStats(rank.Overall),
+		Region:   rank.Region,
+		Regional: newRankStats(rank.Regional),
+	}, nil
+}
+
+func parseGameID(s string) (uuid.UUID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.UUID{}, status.Error(codes.InvalidArgument, "invalid game_id format")
+	}
+	return id, nil
+}
+
+// pageRequest builds a page request the way pageFromQuery does for the HTTP API.
+func pageRequest(offset, limit int32, cursor string) (domain.PageRequest, error) {
+	page := domain.PageRequest{Offset: int(offset), Limit: int(limit)}
+	if cursor != "" {
+		c, err := domain.DecodeCursor(cursor)
+		if err != nil {
+			return domain.PageRequest{}, status.Error(codes.InvalidArgument, err.Error())
+		}
+		page.Cursor = &c
+	}
+	return page, nil
+}
+
+func newLeaderboardPage(page domain.LeaderboardPage) *leaderboardv1.LeaderboardPage {
+	res := &leaderboardv1.LeaderboardPage{
+		Entries:    make([]*leaderboardv1.LeaderboardEntry, 0, len(page.Users)),
+		NextCursor: page.NextCursor,
+		Total:      page.Total,
+	}
+	for _, user := range page.Users {
+		res.Entries = append(res.Entries, &leaderboardv1.LeaderboardEntry{
+			UserId: user.UserID.String(),
+			Score:  user.Score,
+			Rank:   user.Rank,
+		})
+	}
+	return res
+}
+
+func newRankStats(stats domain.RankStats) *leaderboardv1.RankStats {
+	return &leaderboardv1.RankStats{
+		Rank:       stats.Rank,
+		Total:      stats.Total,
+		Percentile: stats.Percentile(),
+	}
+}
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"OnlineLeadership/internal/infrastructure/logger"
+	"OnlineLeadership/internal/infrastructure/metrics"
+	"OnlineLeadership/internal/infrastructure/ratelimit"
+	"OnlineLeadership/internal/interfaces/http/middleware"
+	"context"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// methodRoute names a gRPC method for the rate limiter, e.g.
+// "/grpc/authservice/login" for "/leaderboard.v1.AuthService/Login". The
+// package is left out and the name lower-cased because configuration keys
+// cannot contain dots and are read in lower case.
+func methodRoute(fullMethod string) string {
+	service, method, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if i := strings.LastIndex(service, "."); i >= 0 {
+		service = service[i+1:]
+	}
+	return strings.ToLower("/grpc/" + service + "/" + method)
+}
+
+// peerAddress returns the host of the calling peer, without the port.
+func peerAddress(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	addr := p.Addr.String()
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
+
+// clientIP stores the peer address in the call context for the audit log, as
+// the clientIP middleware does for HTTP requests.
+func clientIP(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return handler(middleware.WithClientIP(ctx, peerAddress(ctx)), req)
+}
+
+func clientIPStream(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx := middleware.WithClientIP(ss.Context(), peerAddress(ss.Context()))
+	return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+}
+
+// rateLimiter applies the method's rate limit to the caller: the user on
+// authenticated methods, the peer address on publicMethods. A stream counts
+// once, when it is opened. Decisions are sent as ratelimit-* header metadata
+// and a rejected call fails with RESOURCE_EXHAUSTED.
+type rateLimiter struct {
+	limiter *ratelimit.Limiter
+	log     *logger.SlogLogger
+}
+
+// allow counts the call and returns the header metadata to send with it, or
+// the error to fail it with.
+func (l *rateLimiter) allow(ctx context.Context, fullMethod string) (metadata.MD, error) {
+	route := methodRoute(fullMethod)
+	client := "ip:" + peerAddress(ctx)
+	if userID, err := authenticatedUser(ctx); err == nil {
+		client = fmt.Sprintf("user:%s", userID)
+	}
+	decision, err := l.limiter.Allow(ctx, route, client)
+	if err != nil {
+		// An unavailable store lets calls through rather than failing them.
+		l.log.Warn(ctx, "rate limit store error", "error", err)
+		return nil, nil
+	}
+
+	reset := strconv.Itoa(ceilSeconds(decision.Reset))
+	header := metadata.Pairs(
+		"ratelimit-limit", strconv.Itoa(decision.Limit),
+		"ratelimit-remaining", strconv.Itoa(decision.Remaining),
+		"ratelimit-reset", reset,
+	)
+	if !decision.Allowed {
+		metrics.ObserveRateLimited(route)
+		header.Set("retry-after", reset)
+		return header, toStatus(ratelimit.ErrLimited)
+	}
+	return header, nil
+}
+
+func (l *rateLimiter) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	header, err := l.allow(ctx, info.FullMethod)
+	if header != nil {
+		if err := grpc.SetHeader(ctx, header); err != nil {
+			l.log.Warn(ctx, "set rate limit header error", "error", err)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (l *rateLimiter) stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	header, err := l.allow(ss.Context(), info.FullMethod)
+	if header != nil {
+		if err := ss.SetHeader(header); err != nil {
+			l.log.Warn(ss.Context(), "set rate limit header error", "error", err)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int((d + time.Second - 1) / time.Second)
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	leaderboardv1 "OnlineLeadership/api/proto/leaderboard/v1"
+	"OnlineLeadership/internal/domain"
+	"OnlineLeadership/internal/infrastructure/logger"
+	"OnlineLeadership/internal/usecase"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+)
+
+// MaxBatchSize is the number of scores SubmitScores accepts in one call.
+const MaxBatchSize = 100
+
+type scoreServer struct {
+	leaderboardv1.UnimplementedScoreServiceServer
+	service *usecase.Service
+	log     *logger.SlogLogger
+}
+
+// submit records one score of the authenticated user.
+func (s *scoreServer) submit(ctx context.Context, req *leaderboardv1.SubmitScoreRequest) error {
+	userID, err := authenticatedUser(ctx)
+	if err != nil {
+		return err
+	}
+	gameID, err := uuid.Parse(req.GetGameId())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid game_id format")
+	}
+	if req.GetScore() < 0 {
+		return status.Error(codes.InvalidArgument, "score must not be negative")
+	}
+	return toStatus(s.service.ScoreHistory.SubmitScore(ctx, domain.ScoreSubmission{
+		UserID:    userID,
+		GameID:    gameID,
+		Score:     int(req.GetScore()),
+		Nonce:     req.GetNonce(),
+		Timestamp: req.GetTimestamp(),
+		Signature: req.GetSignature(),
+	}))
+}
+
+// result reports the outcome of the index-th score of a batch or a stream.
+func result(index int, err error) *leaderboardv1.SubmitScoreResult {
+	st := status.Convert(err)
+	return &leaderboardv1.SubmitScoreResult{
+		Index:   uint32(index),
+		Code:    uint32(st.Code()),
+		Message: st.Message(),
+	}
+}
+
+func (s *scoreServer) SubmitScore(ctx context.Context, req *leaderboardv1.SubmitScoreRequest) (*leaderboardv1.SubmitScoreResponse, error) {
+	if err := s.submit(ctx, req); err != nil {
+		return nil, err
+	}
+	return &leaderboardv1.SubmitScoreResponse{}, nil
+}
+
+func (s *scoreServer) SubmitScores(ctx context.Context, req *leaderboardv1.SubmitScoresRequest) (*leaderboardv1.SubmitScoresResponse, error) {
+	if len(req.GetScores()) > MaxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d scores per batch", MaxBatchSize)
+	}
+	res := &leaderboardv1.SubmitScoresResponse{Results: make([]*leaderboardv1.SubmitScoreResult, 0, len(req.GetScores()))}
+	for i, score := range req.GetScores() {
+		res.Results = append(res.Results, result(i, s.submit(ctx, score)))
+	}
+	s.log.Info(ctx, "grpc submit scores passed", "scores", len(req.GetScores()))
+	return res, nil
+}
+
+func (s *scoreServer) StreamScores(stream grpc.BidiStreamingServer[leaderboardv1.SubmitScoreRequest, leaderboardv1.SubmitScoreResult]) error {
+	ctx := stream.Context()
+	for i := 0; ; i++ {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(result(i, s.submit(ctx, req))); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,67 @@
+// Package server serves the gRPC API defined in api/proto/leaderboard/v1 on
+// top of the same usecases as the HTTP handlers.
+package server
+
+import (
+	leaderboardv1 "OnlineLeadership/api/proto/leaderboard/v1"
+	"OnlineLeadership/internal/infrastructure/logger"
+	"OnlineLeadership/internal/infrastructure/ratelimit"
+	"OnlineLeadership/internal/usecase"
+	"context"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"net"
+)
+
+// Server wraps the underlying grpc.Server.
+type Server struct {
+	grpcServer *grpc.Server
+}
+
+// NewServer registers the services. limiter is nil when rate limiting is
+// disabled; otherwise it is the limiter of the HTTP API, so that both APIs
+// share the same windows.
+func NewServer(service *usecase.Service, log *logger.SlogLogger, limiter *ratelimit.Limiter) *Server {
+	auth := &authenticator{service: service}
+	unary := []grpc.UnaryServerInterceptor{clientIP, auth.unary}
+	stream := []grpc.StreamServerInterceptor{clientIPStream, auth.stream}
+	if limiter != nil {
+		// Runs after authentication so that callers are limited per user.
+		limits := &rateLimiter{limiter: limiter, log: log}
+		unary = append(unary, limits.unary)
+		stream = append(stream, limits.stream)
+	}
+	s := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	)
+	leaderboardv1.RegisterAuthServiceServer(s, &authServer{service: service, log: log})
+	leaderboardv1.RegisterScoreServiceServer(s, &scoreServer{service: service, log: log})
+	leaderboardv1.RegisterLeaderboardServiceServer(s, &leaderboardServer{service: service, log: log})
+	return &Server{grpcServer: s}
+}
+
+// Run serves gRPC on the given port until Shutdown is called.
+func (s *Server) Run(port string) error {
+	lis, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		return err
+	}
+	return s.grpcServer.Serve(lis)
+}
+
+// Shutdown waits for in-flight calls to finish and cancels the calls still
+// running when ctx is done, such as rank watches that never end by themselves.
+func (s *Server) Shutdown(ctx context.Context) {
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		s.grpcServer.Stop()
+	}
+}
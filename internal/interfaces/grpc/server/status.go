@@ -0,0 +1,40 @@
+package server
+
+import (
+	"OnlineLeadership/internal/domain"
+	"OnlineLeadership/internal/infrastructure/ratelimit"
+	"context"
+	"errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatus maps usecase errors to gRPC status codes, the way the HTTP
+// handlers map them to status codes.
+func toStatus(err error) error {
+	var code codes.Code
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, domain.ErrGameNotFound), errors.Is(err, domain.ErrUserNotFound):
+		code = codes.NotFound
+	case errors.Is(err, domain.ErrUserBanned), errors.Is(err, domain.ErrInvalidSignature):
+		code = codes.PermissionDenied
+	case errors.Is(err, domain.ErrInvalidRegion), errors.Is(err, domain.ErrInvalidCursor),
+		errors.Is(err, domain.ErrStaleSubmission), errors.Is(err, domain.ErrScoreOutOfBounds):
+		code = codes.InvalidArgument
+	case errors.Is(err, domain.ErrNonceReused):
+		code = codes.AlreadyExists
+	case errors.Is(err, domain.ErrGameArchived), errors.Is(err, domain.ErrScoreQuarantined):
+		code = codes.FailedPrecondition
+	case errors.Is(err, ratelimit.ErrLimited):
+		code = codes.ResourceExhausted
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	default:
+		code = codes.Internal
+	}
+	return status.Error(code, err.Error())
+}
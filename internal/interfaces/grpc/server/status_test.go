@@ -0,0 +1,64 @@
+package server
+
+import (
+	"OnlineLeadership/internal/domain"
+	"OnlineLeadership/internal/infrastructure/ratelimit"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestToStatus(t *testing.T) {
+	if err := toStatus(nil); err != nil {
+		t.Fatalf("toStatus(nil) = %v, want nil", err)
+	}
+
+	tests := []struct {
+		err  error
+		want codes.Code
+	}{
+		{domain.ErrGameNotFound, codes.NotFound},
+		{domain.ErrUserNotFound, codes.NotFound},
+		{domain.ErrUserBanned, codes.PermissionDenied},
+		{domain.ErrInvalidSignature, codes.PermissionDenied},
+		{domain.ErrInvalidRegion, codes.InvalidArgument},
+		{domain.ErrInvalidCursor, codes.InvalidArgument},
+		{domain.ErrStaleSubmission, codes.InvalidArgument},
+		{domain.ErrScoreOutOfBounds, codes.InvalidArgument},
+		{domain.ErrNonceReused, codes.AlreadyExists},
+		{domain.ErrGameArchived, codes.FailedPrecondition},
+		{domain.ErrScoreQuarantined, codes.FailedPrecondition},
+		{ratelimit.ErrLimited, codes.ResourceExhausted},
+		{context.Canceled, codes.Canceled},
+		{context.DeadlineExceeded, codes.DeadlineExceeded},
+		// Wrapped errors map like the error they wrap.
+		{fmt.Errorf("submit: %w", domain.ErrGameArchived), codes.FailedPrecondition},
+		{errors.New("connection refused"), codes.Internal},
+	}
+	for _, tt := range tests {
+		st, ok := status.FromError(toStatus(tt.err))
+		if !ok || st.Code() != tt.want || st.Message() != tt.err.Error() {
+			t.Errorf("toStatus(%q) = %v, want %s with the error message", tt.err, st, tt.want)
+		}
+	}
+}
+
+func TestMethodRoute(t *testing.T) {
+	tests := []struct {
+		method string
+		want   string
+	}{
+		{"/leaderboard.v1.AuthService/Login", "/grpc/authservice/login"},
+		{"/leaderboard.v1.LeaderboardService/WatchRank", "/grpc/leaderboardservice/watchrank"},
+		{"/Health/Check", "/grpc/health/check"},
+	}
+	for _, tt := range tests {
+		if got := methodRoute(tt.method); got != tt.want {
+			t.Errorf("methodRoute(%q) = %q, want %q", tt.method, got, tt.want)
+		}
+	}
+}
@@ -1,27 +1,50 @@
 package handler
 
 import (
+	"OnlineLeadership/internal/domain"
+	"errors"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"net/http"
 )
 
 // CreateGameInput represents input for creating a game
 type CreateGameInput struct {
-	Name string `json:"name" binding:"required" example:"Chess"`
+	Name        string        `json:"name" binding:"required" example:"Chess"`
+	Slug        string        `json:"slug" example:"chess"`
+	Description string        `json:"description" example:"Classic chess, rated games only"`
+	Config      GameConfigDTO `json:"config"`
+}
+
+// UpdateGameInput represents input for updating a game; all fields are replaced
+type UpdateGameInput struct {
+	Name        string        `json:"name" binding:"required" example:"Chess"`
+	Slug        string        `json:"slug" example:"chess"`
+	Description string        `json:"description" example:"Classic chess, rated games only"`
+	Config      GameConfigDTO `json:"config"`
 }
 
 // @Summary Create a new game
-// @Description Create a new game with given name
+// @Description Create a new game with given name, optional slug (derived from the name when empty), description and config. Requires the admin role
 // @Tags admin
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param input body CreateGameInput true "Game input"
 // @Success 201 {object} GameIDResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /admin/create [post]
 func (h *Handler) createGame(c *gin.Context) {
 	ctx := c.Request.Context()
+	actorID, err := getUserId(c)
+	if err != nil {
+		NewErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
 	var input CreateGameInput
 	if err := c.ShouldBindJSON(&input); err != nil {
 		NewErrorResponse(c, http.StatusBadRequest, err.Error())
@@ -29,9 +52,14 @@ func (h *Handler) createGame(c *gin.Context) {
 	}
 
 	// Service returns uuid.UUID
-	id, err := h.service.Admin.Create(ctx, input.Name)
+	id, err := h.service.Admin.Create(ctx, actorID, domain.Game{
+		Name:        input.Name,
+		Slug:        input.Slug,
+		Description: input.Description,
+		Config:      input.Config.toDomain(),
+	})
 	if err != nil {
-		NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		gameErrorResponse(c, err)
 		return
 	}
 
@@ -42,11 +70,14 @@ func (h *Handler) createGame(c *gin.Context) {
 }
 
 // @Summary Get list of games
-// @Description Returns all games
+// @Description Returns all games, archived and hidden ones included. Requires the admin role
 // @Tags admin
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Success 200 {object} GamesResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /admin/games [get]
 func (h *Handler) getGames(c *gin.Context) {
@@ -57,16 +88,262 @@ func (h *Handler) getGames(c *gin.Context) {
 		return
 	}
 
-	// Convert domain models to DTOs (uuid.UUID -> string)
-	gameDTOs := make([]GameDTO, 0, len(games))
-	for _, game := range games {
-		gameDTOs = append(gameDTOs, GameDTO{
-			ID:   game.Id.String(),
-			Name: game.Name,
-		})
+	c.JSON(http.StatusOK, newGamesResponse(games))
+}
+
+// @Summary Get a game
+// @Description Returns a game with its configuration. Requires the admin role
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Game ID"
+// @Success 200 {object} GameDTO
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/games/{id} [get]
+func (h *Handler) getGame(c *gin.Context) {
+	ctx := c.Request.Context()
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, "invalid game id format")
+		return
+	}
+	game, err := h.service.Admin.GetGame(ctx, id)
+	if err != nil {
+		gameErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newGameDTO(game))
+}
+
+// @Summary Update a game
+// @Description Replaces the name, slug, description and config of a game; the aggregation cannot change once the game has scores. Requires the admin role
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Game ID"
+// @Param input body UpdateGameInput true "Game input"
+// @Success 200 {object} StatusResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/games/{id} [put]
+func (h *Handler) updateGame(c *gin.Context) {
+	ctx := c.Request.Context()
+	actorID, err := getUserId(c)
+	if err != nil {
+		NewErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, "invalid game id format")
+		return
+	}
+	var input UpdateGameInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
 	}
 
-	c.JSON(http.StatusOK, GamesResponse{
-		Data: gameDTOs,
+	err = h.service.Admin.UpdateGame(ctx, actorID, domain.Game{
+		Id:          id,
+		Name:        input.Name,
+		Slug:        input.Slug,
+		Description: input.Description,
+		Config:      input.Config.toDomain(),
 	})
+	if err != nil {
+		gameErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
+}
+
+// @Summary Archive a game
+// @Description Retires a game; its history and leaderboards are kept and it can be restored. Requires the admin role
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Game ID"
+// @Success 200 {object} StatusResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/games/{id}/archive [post]
+func (h *Handler) archiveGame(c *gin.Context) {
+	ctx := c.Request.Context()
+	actorID, err := getUserId(c)
+	if err != nil {
+		NewErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, "invalid game id format")
+		return
+	}
+	if err := h.service.Admin.ArchiveGame(ctx, actorID, id); err != nil {
+		gameErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
+}
+
+// @Summary Restore an archived game
+// @Description Makes an archived game active again. Requires the admin role
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Game ID"
+// @Success 200 {object} StatusResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/games/{id}/restore [post]
+func (h *Handler) restoreGame(c *gin.Context) {
+	ctx := c.Request.Context()
+	actorID, err := getUserId(c)
+	if err != nil {
+		NewErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, "invalid game id format")
+		return
+	}
+	if err := h.service.Admin.RestoreGame(ctx, actorID, id); err != nil {
+		gameErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
+}
+
+// @Summary Rotate a game's signing secret
+// @Description Generates a new HMAC secret for signing score submissions and returns it once; from then on the game only accepts submissions signed with it. Requires the admin role
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Game ID"
+// @Success 200 {object} SigningSecretResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/games/{id}/secret [post]
+func (h *Handler) rotateSigningSecret(c *gin.Context) {
+	ctx := c.Request.Context()
+	actorID, err := getUserId(c)
+	if err != nil {
+		NewErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, "invalid game id format")
+		return
+	}
+	secret, err := h.service.Admin.RotateSigningSecret(ctx, actorID, id)
+	if err != nil {
+		gameErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, SigningSecretResponse{Secret: secret})
+}
+
+// @Summary Disable submission signing
+// @Description Removes a game's signing secret so that it accepts unsigned submissions again. Requires the admin role
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Game ID"
+// @Success 200 {object} StatusResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/games/{id}/secret [delete]
+func (h *Handler) disableSigning(c *gin.Context) {
+	ctx := c.Request.Context()
+	actorID, err := getUserId(c)
+	if err != nil {
+		NewErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, "invalid game id format")
+		return
+	}
+	if err := h.service.Admin.DisableSigning(ctx, actorID, id); err != nil {
+		gameErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
+}
+
+// @Summary Delete a game
+// @Description Deletes a game with its score history and leaderboards, and subtracts its scores from the global leaderboards. Requires the admin role
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Game ID"
+// @Success 200 {object} StatusResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/games/{id} [delete]
+func (h *Handler) deleteGame(c *gin.Context) {
+	ctx := c.Request.Context()
+	actorID, err := getUserId(c)
+	if err != nil {
+		NewErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, "invalid game id format")
+		return
+	}
+	if err := h.service.Admin.DeleteGame(ctx, actorID, id); err != nil {
+		gameErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
+}
+
+// gameErrorResponse maps game domain errors to HTTP statuses.
+func gameErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrGameNotFound):
+		NewErrorResponse(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrGameConflict), errors.Is(err, domain.ErrAggregationLocked):
+		NewErrorResponse(c, http.StatusConflict, err.Error())
+	case errors.Is(err, domain.ErrInvalidGame):
+		NewErrorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
 }
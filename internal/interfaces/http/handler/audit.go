@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"OnlineLeadership/internal/domain"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// @Summary Query the audit log
+// @Description Returns audit log entries of administrative and security-relevant actions, newest first. Pass the returned next_cursor as cursor to fetch the following page. Requires the admin role
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param actor_id query string false "Actor user ID"
+// @Param action query string false "Action, e.g. game.update or auth.login_failed"
+// @Param target_type query string false "Target type" Enums(user, game, score, quarantine, flag)
+// @Param target_id query string false "Target ID"
+// @Param from query string false "Earliest creation time, RFC 3339"
+// @Param to query string false "Creation time upper bound (exclusive), RFC 3339"
+// @Param cursor query string false "Opaque cursor from a previous page"
+// @Param limit query int false "Page size, at most 100" default(50)
+// @Success 200 {object} AuditResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/audit [get]
+func (h *Handler) listAudit(c *gin.Context) {
+	ctx := c.Request.Context()
+	filter, err := auditFilterFromQuery(c)
+	if err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	page, err := h.service.Admin.ListAudit(ctx, filter)
+	if errors.Is(err, domain.ErrInvalidCursor) {
+		NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, newAuditResponse(page))
+}
+
+// auditFilterFromQuery reads the audit log filters from the query string.
+func auditFilterFromQuery(c *gin.Context) (domain.AuditFilter, error) {
+	filter := domain.AuditFilter{
+		Action:     c.Query("action"),
+		TargetType: c.Query("target_type"),
+	}
+	for param, dst := range map[string]**uuid.UUID{"actor_id": &filter.ActorID, "target_id": &filter.TargetID} {
+		if v := c.Query(param); v != "" {
+			id, err := uuid.Parse(v)
+			if err != nil {
+				return domain.AuditFilter{}, errors.New("invalid " + param + " format")
+			}
+			*dst = &id
+		}
+	}
+	var err error
+	if filter.From, err = timeQuery(c, "from"); err != nil {
+		return domain.AuditFilter{}, err
+	}
+	if filter.To, err = timeQuery(c, "to"); err != nil {
+		return domain.AuditFilter{}, err
+	}
+	if l := c.Query("limit"); l != "" {
+		if v, err := strconv.Atoi(l); err == nil && v > 0 {
+			filter.Limit = v
+		}
+	}
+	if cur := c.Query("cursor"); cur != "" {
+		cursor, err := domain.DecodeCursor(cur)
+		if err != nil {
+			return domain.AuditFilter{}, err
+		}
+		filter.Cursor = &cursor
+	}
+	return filter, nil
+}
+
+// timeQuery reads an optional RFC 3339 time from the query string.
+func timeQuery(c *gin.Context, param string) (*time.Time, error) {
+	v := c.Query(param)
+	if v == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, errors.New(param + " must be an RFC 3339 time")
+	}
+	return &t, nil
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"OnlineLeadership/internal/domain"
+	"errors"
 	"github.com/gin-gonic/gin"
 	"net/http"
 )
@@ -11,6 +12,7 @@ type RegisterInput struct {
 	Username string `json:"username" binding:"required" example:"john_doe"`
 	Email    string `json:"email" binding:"required,email" example:"john@example.com"`
 	Password string `json:"password" binding:"required,min=6" example:"password123"`
+	Region   string `json:"region" example:"kz"`
 }
 
 // LoginInput represents user login payload
@@ -43,7 +45,12 @@ func (h *Handler) signUp(c *gin.Context) {
 		Username: input.Username,
 		Password: input.Password,
 		Email:    input.Email,
+		Region:   input.Region,
 	})
+	if errors.Is(err, domain.ErrInvalidRegion) {
+		NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		NewErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
@@ -63,6 +70,7 @@ func (h *Handler) signUp(c *gin.Context) {
 // @Param input body LoginInput true "Login input"
 // @Success 200 {object} LoginResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /auth/login [post]
 func (h *Handler) signIn(c *gin.Context) {
@@ -74,6 +82,10 @@ func (h *Handler) signIn(c *gin.Context) {
 		return
 	}
 	at, rt, err := h.service.Login(ctx, input.Username, input.Password)
+	if errors.Is(err, domain.ErrUserBanned) {
+		NewErrorResponse(c, http.StatusForbidden, err.Error())
+		return
+	}
 	if err != nil {
 		NewErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
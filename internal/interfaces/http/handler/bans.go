@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"OnlineLeadership/internal/domain"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+)
+
+// BanUserInput represents a ban; a zero duration bans permanently
+type BanUserInput struct {
+	Scopes          []string `json:"scopes" binding:"required,min=1,dive,oneof=login submit visibility" example:"submit,visibility"`
+	Reason          string   `json:"reason" binding:"required,max=500" example:"confirmed cheating"`
+	DurationSeconds int64    `json:"duration_seconds" binding:"min=0" example:"604800"`
+}
+
+// @Summary Ban a user
+// @Description Suspends a user for the given scopes: login (logging in and using access tokens), submit (score submission) and visibility (hidden from every leaderboard, scores are kept). A zero duration bans permanently. Requires the admin role
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "User ID"
+// @Param input body BanUserInput true "Ban"
+// @Success 201 {object} BanIDResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/users/{id}/bans [post]
+func (h *Handler) banUser(c *gin.Context) {
+	ctx := c.Request.Context()
+	actorID, err := getUserId(c)
+	if err != nil {
+		NewErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, "invalid user id format")
+		return
+	}
+	var input BanUserInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	duration := time.Duration(input.DurationSeconds) * time.Second
+	id, err := h.service.Bans.Ban(ctx, actorID, userID, input.Scopes, input.Reason, duration)
+	if err != nil {
+		banErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, BanIDResponse{BanID: id.String()})
+}
+
+// @Summary List a user's bans
+// @Description Returns every ban of a user, most recent first, lifted and expired ones included. Requires the admin role
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} BansResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/users/{id}/bans [get]
+func (h *Handler) listBans(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, "invalid user id format")
+		return
+	}
+	bans, err := h.service.Bans.ListBans(ctx, userID)
+	if err != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, newBansResponse(bans))
+}
+
+// @Summary Lift a ban
+// @Description Reinstates a user; lifting a visibility ban restores the user's leaderboard entries from the score history. Requires the admin role
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Ban ID"
+// @Success 200 {object} StatusResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/bans/{id} [delete]
+func (h *Handler) liftBan(c *gin.Context) {
+	ctx := c.Request.Context()
+	actorID, err := getUserId(c)
+	if err != nil {
+		NewErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+	banID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, "invalid ban id format")
+		return
+	}
+	if err := h.service.Bans.LiftBan(ctx, actorID, banID); err != nil {
+		banErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
+}
+
+// banErrorResponse maps ban domain errors to HTTP statuses.
+func banErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound), errors.Is(err, domain.ErrBanNotFound):
+		NewErrorResponse(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrBanLifted):
+		NewErrorResponse(c, http.StatusConflict, err.Error())
+	case errors.Is(err, domain.ErrInvalidBan):
+		NewErrorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
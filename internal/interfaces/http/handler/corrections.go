@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"OnlineLeadership/internal/domain"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+// VoidScoreInput represents a score void request
+type VoidScoreInput struct {
+	Reason string `json:"reason" binding:"required,max=500" example:"submitted with a modified client"`
+}
+
+// AdjustScoreInput represents a score adjustment; delta is added to the user's game score
+type AdjustScoreInput struct {
+	Delta  int    `json:"delta" binding:"required" example:"-500"`
+	Reason string `json:"reason" binding:"required,max=500" example:"refund of a bugged level"`
+}
+
+// RemoveUserInput represents a leaderboard removal; without game_id the user is removed from every leaderboard
+type RemoveUserInput struct {
+	GameID string `json:"game_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Reason string `json:"reason" binding:"required,max=500" example:"confirmed cheating"`
+}
+
+// @Summary Void a score
+// @Description Marks a score history entry as voided and removes it from the game, regional and global leaderboards. Requires the admin role
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Score ID"
+// @Param input body VoidScoreInput true "Reason"
+// @Success 200 {object} StatusResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/scores/{id}/void [post]
+func (h *Handler) voidScore(c *gin.Context) {
+	ctx := c.Request.Context()
+	actorID, err := getUserId(c)
+	if err != nil {
+		NewErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+	scoreID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, "invalid score id format")
+		return
+	}
+	var input VoidScoreInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.service.Moderation.VoidScore(ctx, actorID, scoreID, input.Reason); err != nil {
+		correctionErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
+}
+
+// @Summary Adjust a user's game score
+// @Description Adds delta to a user's score on a game leaderboard and the global leaderboards; the adjustment is kept in the score history. Only games aggregated by sum can be adjusted. Requires the admin role
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Game ID"
+// @Param user_id path string true "User ID"
+// @Param input body AdjustScoreInput true "Adjustment"
+// @Success 200 {object} StatusResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/games/{id}/users/{user_id}/adjust [post]
+func (h *Handler) adjustScore(c *gin.Context) {
+	ctx := c.Request.Context()
+	actorID, err := getUserId(c)
+	if err != nil {
+		NewErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+	gameID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, "invalid game id format")
+		return
+	}
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, "invalid user id format")
+		return
+	}
+	var input AdjustScoreInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.service.Moderation.AdjustScore(ctx, actorID, gameID, userID, input.Delta, input.Reason); err != nil {
+		correctionErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
+}
+
+// @Summary Remove a user from leaderboards
+// @Description Voids a user's scores for one game, or for every game when game_id is empty, and removes the user from the matching leaderboards. Requires the admin role
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "User ID"
+// @Param input body RemoveUserInput true "Removal"
+// @Success 200 {object} StatusResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/users/{id}/remove [post]
+func (h *Handler) removeUser(c *gin.Context) {
+	ctx := c.Request.Context()
+	actorID, err := getUserId(c)
+	if err != nil {
+		NewErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, "invalid user id format")
+		return
+	}
+	var input RemoveUserInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	var gameID *uuid.UUID
+	if input.GameID != "" {
+		id, err := uuid.Parse(input.GameID)
+		if err != nil {
+			NewErrorResponse(c, http.StatusBadRequest, "invalid game_id format")
+			return
+		}
+		gameID = &id
+	}
+	if err := h.service.Moderation.RemoveUser(ctx, actorID, userID, gameID, input.Reason); err != nil {
+		correctionErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
+}
+
+// correctionErrorResponse maps score correction errors to HTTP statuses.
+func correctionErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrScoreNotFound), errors.Is(err, domain.ErrUserNotFound), errors.Is(err, domain.ErrGameNotFound):
+		NewErrorResponse(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrScoreVoided), errors.Is(err, domain.ErrAdjustmentUnsupported):
+		NewErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,271 @@
+package handler
+
+import (
+	"OnlineLeadership/internal/domain"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"hash/fnv"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// @Summary List games
+// @Description Returns the active, public games
+// @Tags games
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} GamesResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/games [get]
+func (h *Handler) listGames(c *gin.Context) {
+	ctx := c.Request.Context()
+	games, err := h.service.Admin.ListGames(ctx)
+	if err != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, newGamesResponse(games))
+}
+
+// @Summary Get leaderboard of a game
+// @Description Returns a page of top players for a game, optionally restricted to a region. Pass the returned next_cursor as cursor to fetch the following page; offset is ignored when a cursor is given. Supports conditional requests with If-None-Match and If-Modified-Since.
+// @Tags leaderboard
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Game ID"
+// @Param offset query int false "Offset" default(0)
+// @Param limit query int false "Limit" default(50) maximum(100)
+// @Param cursor query string false "Opaque cursor from a previous page"
+// @Param region query string false "Region code, e.g. kz or eu-west"
+// @Param If-None-Match header string false "ETag of a previously fetched page"
+// @Param If-Modified-Since header string false "Last-Modified of a previously fetched page"
+// @Success 200 {object} LeaderboardResponse
+// @Success 304 "Not Modified"
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/games/{id}/leaderboard [get]
+func (h *Handler) gameLeaderboard(c *gin.Context) {
+	ctx := c.Request.Context()
+	gameID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, "invalid game id format")
+		return
+	}
+	page, err := pageFromQuery(c)
+	if err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// The version is read before the listing so that the ETag never claims a
+	// newer state than the body it is sent with.
+	version, err := h.service.Leaderboard.GetLeaderboardVersion(ctx, gameID)
+	if err != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	etag := leaderboardETag(version, c.Query("region"), page)
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "private, no-cache")
+	if !version.UpdatedAt.IsZero() {
+		c.Header("Last-Modified", version.UpdatedAt.Format(http.TimeFormat))
+	}
+	if notModified(c.Request, etag, version.UpdatedAt) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	users, err := h.service.Leaderboard.GetLeaderboard(ctx, gameID, c.Query("region"), page)
+	if errors.Is(err, domain.ErrInvalidRegion) {
+		NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, newLeaderboardResponse(users))
+}
+
+// leaderboardETag derives the entity tag of a leaderboard page from the board
+// version and the parameters selecting the page.
+func leaderboardETag(version domain.BoardVersion, region string, page domain.PageRequest) string {
+	hash := fnv.New64a()
+	fmt.Fprintf(hash, "%s|%d|%d", strings.ToLower(region), page.Offset, page.Limit)
+	if page.Cursor != nil {
+		fmt.Fprintf(hash, "|%s", page.Cursor.Encode())
+	}
+	return fmt.Sprintf(`"v%d-%x"`, version.Version, hash.Sum64())
+}
+
+// notModified evaluates If-None-Match and, when it is absent, If-Modified-Since.
+func notModified(r *http.Request, etag string, updatedAt time.Time) bool {
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		for _, candidate := range strings.Split(match, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == etag {
+				return true
+			}
+		}
+		return false
+	}
+	if since := r.Header.Get("If-Modified-Since"); since != "" && !updatedAt.IsZero() {
+		t, err := http.ParseTime(since)
+		return err == nil && !updatedAt.Truncate(time.Second).After(t)
+	}
+	return false
+}
+
+// @Summary Get current user's rank in a game
+// @Description Returns the rank, board size and percentile of the authenticated user on a game leaderboard and on its regional variant
+// @Tags leaderboard
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Game ID"
+// @Success 200 {object} RankResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/games/{id}/rank [get]
+func (h *Handler) gameRank(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := getUserId(c)
+	if err != nil {
+		NewErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+	gameID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, "invalid game id format")
+		return
+	}
+
+	rank, err := h.service.Leaderboard.GetGameRank(ctx, userID, gameID)
+	if err != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, newRankResponse(rank))
+}
+
+// @Summary Get a player's progress in a game
+// @Description Returns a player's rank and score on a game leaderboard over time, from hourly snapshots downsampled to hour, day or week buckets. Defaults to the authenticated user and to the last 7 days for hour, 90 days for day and a year for week buckets
+// @Tags leaderboard
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Game ID"
+// @Param user_id query string false "User ID, defaults to the authenticated user"
+// @Param bucket query string false "Bucket size" Enums(hour, day, week) default(day)
+// @Param from query string false "Start of the period, RFC 3339"
+// @Param to query string false "End of the period (exclusive), RFC 3339"
+// @Success 200 {object} ProgressResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/games/{id}/progress [get]
+func (h *Handler) gameProgress(c *gin.Context) {
+	ctx := c.Request.Context()
+	viewerID, err := getUserId(c)
+	if err != nil {
+		NewErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+	q := domain.ProgressQuery{UserID: viewerID, Bucket: c.Query("bucket")}
+	if q.GameID, err = uuid.Parse(c.Param("id")); err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, "invalid game id format")
+		return
+	}
+	if v := c.Query("user_id"); v != "" {
+		if q.UserID, err = uuid.Parse(v); err != nil {
+			NewErrorResponse(c, http.StatusBadRequest, "invalid user_id format")
+			return
+		}
+	}
+	for param, dst := range map[string]*time.Time{"from": &q.From, "to": &q.To} {
+		t, err := timeQuery(c, param)
+		if err != nil {
+			NewErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if t != nil {
+			*dst = *t
+		}
+	}
+
+	series, err := h.service.Progress.GetProgress(ctx, viewerID, q)
+	switch {
+	case errors.Is(err, domain.ErrInvalidBucket), errors.Is(err, domain.ErrInvalidRange):
+		NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	case errors.Is(err, domain.ErrGameNotFound):
+		NewErrorResponse(c, http.StatusNotFound, err.Error())
+		return
+	case err != nil:
+		NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, newProgressResponse(series))
+}
+
+// @Summary Get score distribution of a game
+// @Description Returns a histogram of the scores on a game leaderboard. Results are cached for a short time.
+// @Tags leaderboard
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Game ID"
+// @Param buckets query int false "Number of buckets" default(10) maximum(50)
+// @Param region query string false "Region code, e.g. kz or eu-west"
+// @Success 200 {object} DistributionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/games/{id}/distribution [get]
+func (h *Handler) scoreDistribution(c *gin.Context) {
+	ctx := c.Request.Context()
+	gameID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, "invalid game id format")
+		return
+	}
+	buckets := 0
+	if b := c.Query("buckets"); b != "" {
+		if v, err := strconv.Atoi(b); err == nil && v > 0 {
+			buckets = v
+		}
+	}
+
+	dist, err := h.service.Leaderboard.GetDistribution(ctx, gameID, c.Query("region"), buckets)
+	if errors.Is(err, domain.ErrInvalidRegion) {
+		NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	bucketDTOs := make([]ScoreBucketDTO, 0, len(dist.Buckets))
+	for _, b := range dist.Buckets {
+		bucketDTOs = append(bucketDTOs, ScoreBucketDTO{
+			From:  b.From,
+			To:    b.To,
+			Count: b.Count,
+		})
+	}
+	c.JSON(http.StatusOK, DistributionResponse{
+		Total:   dist.Total,
+		Min:     dist.Min,
+		Max:     dist.Max,
+		Buckets: bucketDTOs,
+	})
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"OnlineLeadership/internal/domain"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestLeaderboardETag(t *testing.T) {
+	v1 := domain.BoardVersion{Version: 1}
+	page := domain.PageRequest{Offset: 0, Limit: 10}
+	base := leaderboardETag(v1, "eu", page)
+
+	if got := leaderboardETag(v1, "EU", page); got != base {
+		t.Errorf("region case changed the tag: %s != %s", got, base)
+	}
+	cursor := &domain.Cursor{Score: 10, Member: "a"}
+	for name, etag := range map[string]string{
+		"version": leaderboardETag(domain.BoardVersion{Version: 2}, "eu", page),
+		"region":  leaderboardETag(v1, "us", page),
+		"offset":  leaderboardETag(v1, "eu", domain.PageRequest{Offset: 10, Limit: 10}),
+		"limit":   leaderboardETag(v1, "eu", domain.PageRequest{Limit: 20}),
+		"cursor":  leaderboardETag(v1, "eu", domain.PageRequest{Limit: 10, Cursor: cursor}),
+	} {
+		if etag == base {
+			t.Errorf("changing the %s kept the tag %s", name, etag)
+		}
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	const etag = `"v3-abc"`
+	updatedAt := time.Date(2024, 3, 1, 12, 0, 0, 500_000_000, time.UTC)
+	tests := []struct {
+		name    string
+		headers map[string]string
+		updated time.Time
+		want    bool
+	}{
+		{"no conditions", nil, updatedAt, false},
+		{"matching tag", map[string]string{"If-None-Match": etag}, updatedAt, true},
+		{"weak tag", map[string]string{"If-None-Match": "W/" + etag}, updatedAt, true},
+		{"tag in list", map[string]string{"If-None-Match": `"v2-abc", ` + etag}, updatedAt, true},
+		{"any tag", map[string]string{"If-None-Match": "*"}, updatedAt, true},
+		{"other tag", map[string]string{"If-None-Match": `"v2-abc"`}, updatedAt, false},
+		{"tag wins over date", map[string]string{"If-None-Match": `"v2-abc"`, "If-Modified-Since": updatedAt.Add(time.Hour).Format(http.TimeFormat)}, updatedAt, false},
+		{"same second", map[string]string{"If-Modified-Since": updatedAt.Format(http.TimeFormat)}, updatedAt, true},
+		{"modified since", map[string]string{"If-Modified-Since": updatedAt.Add(-time.Second).Format(http.TimeFormat)}, updatedAt, false},
+		{"unparsable date", map[string]string{"If-Modified-Since": "yesterday"}, updatedAt, false},
+		{"never written", map[string]string{"If-Modified-Since": updatedAt.Format(http.TimeFormat)}, time.Time{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+			if got := notModified(r, etag, tt.updated); got != tt.want {
+				t.Fatalf("notModified() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
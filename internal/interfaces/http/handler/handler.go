@@ -1,53 +1,116 @@
 package handler
 
 import (
+	"OnlineLeadership/internal/domain"
 	"OnlineLeadership/internal/infrastructure/logger"
+	"OnlineLeadership/internal/infrastructure/ratelimit"
 	"OnlineLeadership/internal/usecase"
 
 	"github.com/gin-gonic/gin"
 	files "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
+// serviceName names the server in the request spans.
+const serviceName = "leaderboard"
+
 type Handler struct {
 	service *usecase.Service
 	log     *logger.SlogLogger
+	// limiter is nil when rate limiting is disabled.
+	limiter *ratelimit.Limiter
 }
 
-func NewHandler(service *usecase.Service, log *logger.SlogLogger) *Handler {
-	return &Handler{service: service, log: log}
+func NewHandler(service *usecase.Service, log *logger.SlogLogger, limiter *ratelimit.Limiter) *Handler {
+	return &Handler{service: service, log: log, limiter: limiter}
 }
 
 func (h *Handler) InitRouter() *gin.Engine {
 	r := gin.New()
-	r.Use(gin.Logger(), gin.Recovery())
+	// The request span is started first so that it covers the whole chain and
+	// continues the W3C trace context sent by the caller.
+	r.Use(otelgin.Middleware(serviceName, otelgin.WithGinFilter(traceable)))
+	r.Use(gin.Logger(), observeRequest, gin.Recovery(), clientIP)
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(files.Handler))
 
-	// Auth endpoints
-	auth := r.Group("/auth")
+	// Probes for the orchestrator
+	r.GET("/healthz", h.healthz)
+	r.GET("/readyz", h.readyz)
+
+	// Auth endpoints, rate limited per client address
+	auth := r.Group("/auth", h.rateLimit)
 	{
 		auth.POST("/register", h.signUp)
 		auth.POST("/login", h.signIn)
 	}
 
-	// Admin endpoints
-	admin := r.Group("/admin")
+	// Admin endpoints; changes are written to the audit log with the acting user's id.
+	admin := r.Group("/admin", h.userIdentity, h.rateLimit)
 	{
-		admin.POST("/create", h.createGame)
-		admin.GET("/games", h.getGames)
+		// Moderation requires a moderator or admin account.
+		flags := admin.Group("/flags", h.requireRole(domain.RoleModerator, domain.RoleAdmin))
+		{
+			flags.GET("", h.listFlags)
+			flags.POST("/:id/approve", h.approveFlag)
+			flags.POST("/:id/reject", h.rejectFlag)
+		}
+
+		// Everything else requires an admin account.
+		admins := admin.Group("", h.requireRole(domain.RoleAdmin))
+		{
+			admins.POST("/create", h.createGame)
+			admins.GET("/games", h.getGames)
+			admins.GET("/games/:id", h.getGame)
+			admins.PUT("/games/:id", h.updateGame)
+			admins.DELETE("/games/:id", h.deleteGame)
+			admins.POST("/games/:id/archive", h.archiveGame)
+			admins.POST("/games/:id/restore", h.restoreGame)
+			admins.POST("/games/:id/secret", h.rotateSigningSecret)
+			admins.DELETE("/games/:id/secret", h.disableSigning)
+			admins.GET("/quarantine", h.listQuarantine)
+			admins.POST("/quarantine/:id/release", h.releaseQuarantined)
+			admins.POST("/quarantine/:id/discard", h.discardQuarantined)
+			admins.POST("/scores/:id/void", h.voidScore)
+			admins.POST("/games/:id/users/:user_id/adjust", h.adjustScore)
+			admins.POST("/users/:id/remove", h.removeUser)
+			admins.POST("/users/:id/bans", h.banUser)
+			admins.GET("/users/:id/bans", h.listBans)
+			admins.DELETE("/bans/:id", h.liftBan)
+			admins.GET("/audit", h.listAudit)
+		}
 	}
 
-	// Protected API endpoints
-	api := r.Group("/api", h.userIdentity)
+	// Protected API endpoints, rate limited per user
+	api := r.Group("/api", h.userIdentity, h.rateLimit)
 	{
 		score := api.Group("/score")
 		{
 			score.POST("/submit", h.submitScore)
 		}
+		scores := api.Group("/scores")
+		{
+			scores.POST("/:id/flag", h.flagScore)
+		}
+		me := api.Group("/me")
+		{
+			me.GET("", h.getProfile)
+			me.PATCH("", h.updateProfile)
+			me.GET("/scores", h.myScores)
+		}
+		games := api.Group("/games")
+		{
+			games.GET("", h.listGames)
+			games.GET("/:id/leaderboard", h.gameLeaderboard)
+			games.GET("/:id/rank", h.gameRank)
+			games.GET("/:id/progress", h.gameProgress)
+			games.GET("/:id/distribution", h.scoreDistribution)
+		}
 		leaderboard := api.Group("/leaderboard")
 		{
 			leaderboard.GET("/global", h.globalLeaderboard)
 			leaderboard.GET("/my", h.myRank)
+			// Deprecated: use GET /api/games/:id/leaderboard.
 			leaderboard.POST("/top", h.topPlayers)
 		}
 	}
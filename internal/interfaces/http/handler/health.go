@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"OnlineLeadership/internal/domain"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// @Summary Liveness probe
+// @Description Reports that the process is up; it checks no dependencies
+// @Tags health
+// @Produce json
+// @Success 200 {object} StatusResponse
+// @Router /healthz [get]
+func (h *Handler) healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, StatusResponse{Status: domain.HealthOK})
+}
+
+// @Summary Readiness probe
+// @Description Checks Postgres, Redis and the schema version, and fails once the instance starts shutting down
+// @Tags health
+// @Produce json
+// @Success 200 {object} HealthResponse
+// @Failure 503 {object} HealthResponse
+// @Router /readyz [get]
+func (h *Handler) readyz(c *gin.Context) {
+	report := h.service.Health.Ready(c.Request.Context())
+	status := http.StatusOK
+	if report.Status != domain.HealthOK {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, newHealthResponse(report))
+}
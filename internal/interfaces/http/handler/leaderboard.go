@@ -1,6 +1,9 @@
 package handler
 
 import (
+	"OnlineLeadership/internal/domain"
+	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
@@ -11,62 +14,52 @@ import (
 // TopPlayersInput represents input for getting top players
 type TopPlayersInput struct {
 	GameID string `json:"game_id" binding:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Region string `json:"region" example:"kz"`
+	Limit  int    `json:"limit" example:"50"`
+	Cursor string `json:"cursor" example:""`
 }
 
 // @Summary Get global leaderboard
-// @Description Returns a paginated global leaderboard
+// @Description Returns a paginated global leaderboard. Pass the returned next_cursor as cursor to fetch the following page; offset is ignored when a cursor is given.
 // @Tags leaderboard
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
 // @Param offset query int false "Offset" default(0)
 // @Param limit query int false "Limit" default(50) maximum(100)
+// @Param cursor query string false "Opaque cursor from a previous page"
+// @Param region query string false "Region code, e.g. kz or eu-west"
 // @Success 200 {object} LeaderboardResponse
+// @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/leaderboard/global [get]
 func (h *Handler) globalLeaderboard(c *gin.Context) {
-	limit := 50
-	offset := 0
 	ctx := c.Request.Context()
-	if o := c.Query("offset"); o != "" {
-		if v, err := strconv.Atoi(o); err == nil && v >= 0 {
-			offset = v
-		}
-	}
-
-	if l := c.Query("limit"); l != "" {
-		if v, err := strconv.Atoi(l); err == nil && v > 0 && v <= 100 {
-			limit = v
-		}
+	page, err := pageFromQuery(c)
+	if err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
 	}
 	users, err := h.service.GetGlobalLeaderboard(
 		ctx,
-		offset,
-		limit,
+		c.Query("region"),
+		page,
 	)
+	if errors.Is(err, domain.ErrInvalidRegion) {
+		NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		h.log.Error(ctx, "get global leaderboard failed", err)
 		NewErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	// Convert domain models to DTOs (uuid.UUID -> string)
-	userDTOs := make([]LeaderboardUserDTO, 0, len(users))
-	for _, user := range users {
-		userDTOs = append(userDTOs, LeaderboardUserDTO{
-			UserID: user.UserID.String(),
-			Score:  user.Score,
-			Rank:   user.Rank,
-		})
-	}
-
-	c.JSON(http.StatusOK, LeaderboardResponse{
-		Data: userDTOs,
-	})
+	c.JSON(http.StatusOK, newLeaderboardResponse(users))
 }
 
 // @Summary Get current user's rank
-// @Description Returns the rank of the authenticated user in the global leaderboard
+// @Description Returns the rank, board size and percentile of the authenticated user in the global leaderboard and in their regional leaderboard
 // @Tags leaderboard
 // @Accept json
 // @Produce json
@@ -87,13 +80,12 @@ func (h *Handler) myRank(c *gin.Context) {
 		NewErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
-	c.JSON(http.StatusOK, RankResponse{
-		Rank: rank,
-	})
+	c.JSON(http.StatusOK, newRankResponse(rank))
 }
 
 // @Summary Get top players for a game
-// @Description Returns the top players for a specified game
+// @Description Deprecated alias of GET /api/games/{id}/leaderboard. Returns a page of top players for a specified game, optionally restricted to a region
+// @Deprecated
 // @Tags leaderboard
 // @Accept json
 // @Security ApiKeyAuth
@@ -119,23 +111,52 @@ func (h *Handler) topPlayers(c *gin.Context) {
 		return
 	}
 
-	users, err := h.service.Leaderboard.GetLeaderboard(ctx, gameID)
+	page := domain.PageRequest{Limit: req.Limit}
+	if req.Cursor != "" {
+		cursor, err := domain.DecodeCursor(req.Cursor)
+		if err != nil {
+			NewErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		page.Cursor = &cursor
+	}
+
+	c.Header("Deprecation", "true")
+	c.Header("Link", fmt.Sprintf("</api/games/%s/leaderboard>; rel=\"successor-version\"", gameID))
+
+	users, err := h.service.Leaderboard.GetLeaderboard(ctx, gameID, req.Region, page)
+	if errors.Is(err, domain.ErrInvalidRegion) {
+		NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		NewErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	// Convert domain models to DTOs (uuid.UUID -> string)
-	userDTOs := make([]LeaderboardUserDTO, 0, len(users))
-	for _, user := range users {
-		userDTOs = append(userDTOs, LeaderboardUserDTO{
-			UserID: user.UserID.String(),
-			Score:  user.Score,
-			Rank:   user.Rank,
-		})
-	}
+	c.JSON(http.StatusOK, newLeaderboardResponse(users))
+}
 
-	c.JSON(http.StatusOK, LeaderboardResponse{
-		Data: userDTOs,
-	})
+// pageFromQuery reads the offset, limit and cursor query parameters.
+// Page sizes are capped by the service, so any positive limit is accepted here.
+func pageFromQuery(c *gin.Context) (domain.PageRequest, error) {
+	var page domain.PageRequest
+	if o := c.Query("offset"); o != "" {
+		if v, err := strconv.Atoi(o); err == nil && v >= 0 {
+			page.Offset = v
+		}
+	}
+	if l := c.Query("limit"); l != "" {
+		if v, err := strconv.Atoi(l); err == nil && v > 0 {
+			page.Limit = v
+		}
+	}
+	if cur := c.Query("cursor"); cur != "" {
+		cursor, err := domain.DecodeCursor(cur)
+		if err != nil {
+			return domain.PageRequest{}, err
+		}
+		page.Cursor = &cursor
+	}
+	return page, nil
 }
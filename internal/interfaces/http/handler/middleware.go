@@ -1,12 +1,19 @@
 package handler
 
 import (
+	"OnlineLeadership/internal/domain"
+	"OnlineLeadership/internal/infrastructure/metrics"
+	"OnlineLeadership/internal/infrastructure/ratelimit"
+	"OnlineLeadership/internal/interfaces/http/middleware"
 	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -18,7 +25,7 @@ const (
 //
 // Swagger annotations for documentation generators (e.g., swaggo):
 // @Summary Authenticate user by access token (middleware)
-// @Description Parses the "Authorization: Bearer {token}" header, validates the access token and stores the user id in the Gin context under key `UserId`.
+// @Description Parses the "Authorization: Bearer {token}" header, validates the access token, rejects users with a login ban and stores the user id in the Gin context under key `UserId`.
 // @Tags middleware
 // @Accept json
 // @Produce json
@@ -43,11 +50,113 @@ func (h *Handler) userIdentity(c *gin.Context) {
 		return
 	}
 
+	// Tokens issued before a login ban stop working as soon as it is in force.
+	if err := h.service.Bans.CheckBan(c.Request.Context(), userId, domain.BanScopeLogin); err != nil {
+		if errors.Is(err, domain.ErrUserBanned) {
+			NewErrorResponse(c, http.StatusForbidden, err.Error())
+			return
+		}
+		NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
 	// Store uuid.UUID in context
 	c.Set(userCtx, userId)
 	c.Next()
 }
 
+// observeRequest is a Gin middleware that records the request count and latency
+// per route and status. Requests matching no route share the "unmatched" label.
+func observeRequest(c *gin.Context) {
+	start := time.Now()
+	c.Next()
+	route := c.FullPath()
+	if route == "" {
+		route = "unmatched"
+	}
+	metrics.ObserveRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+}
+
+// traceable keeps the Swagger UI assets and the health probes out of the request traces.
+func traceable(c *gin.Context) bool {
+	switch path := c.Request.URL.Path; {
+	case strings.HasPrefix(path, "/swagger/"), path == "/healthz", path == "/readyz":
+		return false
+	}
+	return true
+}
+
+// clientIP is a Gin middleware that stores the client address, as resolved by Gin's
+// trusted proxy settings, in the request context for the audit log.
+func clientIP(c *gin.Context) {
+	c.Request = c.Request.WithContext(middleware.WithClientIP(c.Request.Context(), c.ClientIP()))
+	c.Next()
+}
+
+// rateLimit is a Gin middleware that applies the route's rate limit to the
+// client: the user on routes behind userIdentity, the client address on the
+// others. It sets the RateLimit-* headers of draft-ietf-httpapi-ratelimit-headers
+// and answers 429 with Retry-After once the limit is reached.
+func (h *Handler) rateLimit(c *gin.Context) {
+	if h.limiter == nil {
+		c.Next()
+		return
+	}
+	route := c.FullPath()
+	client := "ip:" + c.ClientIP()
+	if userID, ok := c.Get(userCtx); ok {
+		client = fmt.Sprintf("user:%s", userID)
+	}
+	decision, err := h.limiter.Allow(c.Request.Context(), route, client)
+	if err != nil {
+		// An unavailable store lets requests through rather than failing them.
+		h.log.Warn(c.Request.Context(), "rate limit store error", "error", err)
+		c.Next()
+		return
+	}
+
+	policy := h.limiter.Policy(route)
+	reset := strconv.Itoa(ceilSeconds(decision.Reset))
+	header := c.Writer.Header()
+	header.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", policy.Requests, ceilSeconds(policy.Window)))
+	header.Set("RateLimit-Limit", strconv.Itoa(decision.Limit))
+	header.Set("RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+	header.Set("RateLimit-Reset", reset)
+	if !decision.Allowed {
+		metrics.ObserveRateLimited(route)
+		header.Set("Retry-After", reset)
+		NewErrorResponse(c, http.StatusTooManyRequests, ratelimit.ErrLimited.Error())
+		return
+	}
+	c.Next()
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int((d + time.Second - 1) / time.Second)
+}
+
+// requireRole returns a Gin middleware that lets through only users with one of the given roles.
+// It must run after userIdentity.
+func (h *Handler) requireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := getUserId(c)
+		if err != nil {
+			NewErrorResponse(c, http.StatusUnauthorized, err.Error())
+			return
+		}
+		user, err := h.service.Profile.GetProfile(c.Request.Context(), userID)
+		if err != nil {
+			NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if !user.HasRole(roles...) {
+			NewErrorResponse(c, http.StatusForbidden, domain.ErrForbidden.Error())
+			return
+		}
+		c.Next()
+	}
+}
+
 var ErrUserNotAuthorized = errors.New("user not authorized")
 
 // getUserId retrieves the user UUID stored in Gin context by the userIdentity middleware.
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"OnlineLeadership/internal/domain"
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"strconv"
+)
+
+// FlagScoreInput represents a score report
+type FlagScoreInput struct {
+	Reason string `json:"reason" binding:"required,max=500" example:"impossible time on level 3"`
+}
+
+// @Summary Flag a score
+// @Description Reports a score history entry as suspicious; moderators review it in the moderation queue
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Score ID"
+// @Param input body FlagScoreInput true "Report"
+// @Success 201 {object} FlagIDResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/scores/{id}/flag [post]
+func (h *Handler) flagScore(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := getUserId(c)
+	if err != nil {
+		NewErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+	scoreID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, "invalid score id format")
+		return
+	}
+	var input FlagScoreInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id, err := h.service.Moderation.FlagScore(ctx, userID, scoreID, input.Reason)
+	if err != nil {
+		flagErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, FlagIDResponse{FlagID: id.String()})
+}
+
+// @Summary List moderation flags
+// @Description Returns flagged scores, oldest first. Requires the moderator or admin role
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param status query string false "Status filter" Enums(pending, approved, rejected) default(pending)
+// @Param offset query int false "Offset" default(0)
+// @Param limit query int false "Page size, at most 100" default(50)
+// @Success 200 {object} FlagsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/flags [get]
+func (h *Handler) listFlags(c *gin.Context) {
+	ctx := c.Request.Context()
+	status := c.DefaultQuery("status", domain.FlagPending)
+	switch status {
+	case domain.FlagPending, domain.FlagApproved, domain.FlagRejected:
+	default:
+		NewErrorResponse(c, http.StatusBadRequest, "status must be pending, approved or rejected")
+		return
+	}
+	offset, _ := strconv.Atoi(c.Query("offset"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	flags, err := h.service.Moderation.ListFlags(ctx, status, offset, limit)
+	if err != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, newFlagsResponse(flags))
+}
+
+// @Summary Approve a flagged score
+// @Description Keeps the flagged score and resolves every pending flag on it. Requires the moderator or admin role
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Flag ID"
+// @Success 200 {object} StatusResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/flags/{id}/approve [post]
+func (h *Handler) approveFlag(c *gin.Context) {
+	h.decideFlag(c, h.service.Moderation.ApproveFlag)
+}
+
+// @Summary Reject a flagged score
+// @Description Rejects the flagged score, removes it from the game and global leaderboards and resolves every pending flag on it. Requires the moderator or admin role
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Flag ID"
+// @Success 200 {object} StatusResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/flags/{id}/reject [post]
+func (h *Handler) rejectFlag(c *gin.Context) {
+	h.decideFlag(c, h.service.Moderation.RejectFlag)
+}
+
+func (h *Handler) decideFlag(c *gin.Context, decide func(ctx context.Context, id uuid.UUID, moderatorID uuid.UUID) error) {
+	ctx := c.Request.Context()
+	moderatorID, err := getUserId(c)
+	if err != nil {
+		NewErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, "invalid flag id format")
+		return
+	}
+	if err := decide(ctx, id, moderatorID); err != nil {
+		flagErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
+}
+
+// flagErrorResponse maps moderation domain errors to HTTP statuses.
+func flagErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrFlagNotFound), errors.Is(err, domain.ErrScoreNotFound):
+		NewErrorResponse(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrFlagResolved), errors.Is(err, domain.ErrAlreadyFlagged):
+		NewErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
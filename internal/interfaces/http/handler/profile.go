@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"OnlineLeadership/internal/domain"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"strconv"
+)
+
+// UpdateProfileInput represents profile update payload
+type UpdateProfileInput struct {
+	Region string `json:"region" example:"kz"`
+}
+
+// @Summary Get current user's profile
+// @Description Returns the profile of the authenticated user
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} ProfileResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/me [get]
+func (h *Handler) getProfile(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := getUserId(c)
+	if err != nil {
+		NewErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+	user, err := h.service.Profile.GetProfile(ctx, userID)
+	if err != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, ProfileResponse{
+		UserID:   user.Id.String(),
+		Username: user.Username,
+		Email:    user.Email,
+		Region:   user.Region,
+		Role:     user.Role,
+	})
+}
+
+// @Summary Update current user's profile
+// @Description Changes the region of the authenticated user; existing scores are moved to the new regional leaderboards
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param input body UpdateProfileInput true "Profile fields"
+// @Success 200 {object} StatusResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/me [patch]
+func (h *Handler) updateProfile(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := getUserId(c)
+	if err != nil {
+		NewErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+	var input UpdateProfileInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = h.service.Profile.UpdateRegion(ctx, userID, input.Region)
+	if errors.Is(err, domain.ErrInvalidRegion) {
+		NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, StatusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Get current user's score history
+// @Description Returns the authenticated user's score history entries, newest first, with per-game aggregates (best, average, count and total) of all accepted entries matching the filters. Pass the returned next_cursor as cursor to fetch the following page
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param game_id query string false "Game ID"
+// @Param from query string false "Earliest submission time, RFC 3339"
+// @Param to query string false "Submission time upper bound (exclusive), RFC 3339"
+// @Param cursor query string false "Opaque cursor from a previous page"
+// @Param limit query int false "Page size, at most 100" default(50)
+// @Success 200 {object} ScoreHistoryResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/me/scores [get]
+func (h *Handler) myScores(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := getUserId(c)
+	if err != nil {
+		NewErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+	filter, err := scoreFilterFromQuery(c)
+	if err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	filter.UserID = userID
+
+	page, err := h.service.Profile.ListScores(ctx, filter)
+	if errors.Is(err, domain.ErrInvalidCursor) {
+		NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		h.log.Error(ctx, "list user scores failed", err)
+		NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, newScoreHistoryResponse(page))
+}
+
+// scoreFilterFromQuery reads the score history filters from the query string.
+func scoreFilterFromQuery(c *gin.Context) (domain.ScoreHistoryFilter, error) {
+	var filter domain.ScoreHistoryFilter
+	if v := c.Query("game_id"); v != "" {
+		gameID, err := uuid.Parse(v)
+		if err != nil {
+			return domain.ScoreHistoryFilter{}, errors.New("invalid game_id format")
+		}
+		filter.GameID = &gameID
+	}
+	var err error
+	if filter.From, err = timeQuery(c, "from"); err != nil {
+		return domain.ScoreHistoryFilter{}, err
+	}
+	if filter.To, err = timeQuery(c, "to"); err != nil {
+		return domain.ScoreHistoryFilter{}, err
+	}
+	if l := c.Query("limit"); l != "" {
+		if v, err := strconv.Atoi(l); err == nil && v > 0 {
+			filter.Limit = v
+		}
+	}
+	if cur := c.Query("cursor"); cur != "" {
+		cursor, err := domain.DecodeCursor(cur)
+		if err != nil {
+			return domain.ScoreHistoryFilter{}, err
+		}
+		filter.Cursor = &cursor
+	}
+	return filter, nil
+}
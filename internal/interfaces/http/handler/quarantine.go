@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"OnlineLeadership/internal/domain"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"strconv"
+)
+
+// @Summary List quarantined scores
+// @Description Returns submissions held by the anti-cheat rules, oldest first. Requires the admin role
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param status query string false "Status filter" Enums(pending, released, discarded) default(pending)
+// @Param offset query int false "Offset" default(0)
+// @Param limit query int false "Page size, at most 100" default(50)
+// @Success 200 {object} QuarantineResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/quarantine [get]
+func (h *Handler) listQuarantine(c *gin.Context) {
+	ctx := c.Request.Context()
+	status := c.DefaultQuery("status", domain.QuarantinePending)
+	switch status {
+	case domain.QuarantinePending, domain.QuarantineReleased, domain.QuarantineDiscarded:
+	default:
+		NewErrorResponse(c, http.StatusBadRequest, "status must be pending, released or discarded")
+		return
+	}
+	offset, _ := strconv.Atoi(c.Query("offset"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	scores, err := h.service.ScoreHistory.ListQuarantine(ctx, status, offset, limit)
+	if err != nil {
+		NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, newQuarantineResponse(scores))
+}
+
+// @Summary Release a quarantined score
+// @Description Accepts a pending submission and applies it to the leaderboards. Requires the admin role
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Quarantined score ID"
+// @Success 200 {object} StatusResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/quarantine/{id}/release [post]
+func (h *Handler) releaseQuarantined(c *gin.Context) {
+	ctx := c.Request.Context()
+	actorID, err := getUserId(c)
+	if err != nil {
+		NewErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, "invalid quarantine id format")
+		return
+	}
+	if err := h.service.ScoreHistory.ReleaseQuarantined(ctx, actorID, id); err != nil {
+		quarantineErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
+}
+
+// @Summary Discard a quarantined score
+// @Description Rejects a pending submission; it never reaches the leaderboards. Requires the admin role
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Quarantined score ID"
+// @Success 200 {object} StatusResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/quarantine/{id}/discard [post]
+func (h *Handler) discardQuarantined(c *gin.Context) {
+	ctx := c.Request.Context()
+	actorID, err := getUserId(c)
+	if err != nil {
+		NewErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		NewErrorResponse(c, http.StatusBadRequest, "invalid quarantine id format")
+		return
+	}
+	if err := h.service.ScoreHistory.DiscardQuarantined(ctx, actorID, id); err != nil {
+		quarantineErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
+}
+
+// quarantineErrorResponse maps quarantine domain errors to HTTP statuses.
+func quarantineErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrQuarantineNotFound), errors.Is(err, domain.ErrGameNotFound):
+		NewErrorResponse(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrQuarantineResolved):
+		NewErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -1,8 +1,11 @@
 package handler
 
 import (
+	"OnlineLeadership/internal/domain"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"log/slog"
+	"time"
 )
 
 // ErrorResponse represents an API error response
@@ -17,7 +20,39 @@ type StatusResponse struct {
 
 // RankResponse represents user rank response
 type RankResponse struct {
-	Rank int64 `json:"rank" example:"1"`
+	Rank               int64   `json:"rank" example:"48213"`
+	Total              int64   `json:"total" example:"690000"`
+	Percentile         float64 `json:"percentile" example:"93.01"`
+	TopPercent         float64 `json:"top_percent" example:"6.99"`
+	Region             string  `json:"region,omitempty" example:"kz"`
+	RegionalRank       int64   `json:"regional_rank,omitempty" example:"1520"`
+	RegionalTotal      int64   `json:"regional_total,omitempty" example:"21000"`
+	RegionalPercentile float64 `json:"regional_percentile,omitempty" example:"92.76"`
+	RegionalTopPercent float64 `json:"regional_top_percent,omitempty" example:"7.24"`
+}
+
+// ScoreBucketDTO represents one histogram bucket, bounds are inclusive
+type ScoreBucketDTO struct {
+	From  int64 `json:"from" example:"0"`
+	To    int64 `json:"to" example:"99"`
+	Count int64 `json:"count" example:"1500"`
+}
+
+// DistributionResponse represents a game's score histogram
+type DistributionResponse struct {
+	Total   int64            `json:"total" example:"690000"`
+	Min     int64            `json:"min" example:"0"`
+	Max     int64            `json:"max" example:"99999"`
+	Buckets []ScoreBucketDTO `json:"buckets"`
+}
+
+// ProfileResponse represents the authenticated user's profile
+type ProfileResponse struct {
+	UserID   string `json:"user_id" example:"01234567-89ab-cdef-0123-456789abcdef"`
+	Username string `json:"username" example:"john_doe"`
+	Email    string `json:"email" example:"john@example.com"`
+	Region   string `json:"region" example:"kz"`
+	Role     string `json:"role" enums:"player,moderator,admin" example:"player"`
 }
 
 // LeaderboardUserDTO represents a user entry in the leaderboard
@@ -29,13 +64,58 @@ type LeaderboardUserDTO struct {
 
 // LeaderboardResponse represents leaderboard list response
 type LeaderboardResponse struct {
-	Data []LeaderboardUserDTO `json:"data"`
+	Data       []LeaderboardUserDTO `json:"data"`
+	NextCursor string               `json:"next_cursor,omitempty" example:"MTIzNDU6MDEyMzQ1Njc"`
+	Total      int64                `json:"total" example:"690000"`
+}
+
+// GameConfigDTO represents per-game settings
+type GameConfigDTO struct {
+	MinScore    *int64 `json:"min_score,omitempty" example:"0"`
+	MaxScore    *int64 `json:"max_score,omitempty" example:"100000"`
+	Aggregation string `json:"aggregation,omitempty" enums:"sum,max" example:"sum"`
+	Visibility  string `json:"visibility,omitempty" enums:"public,hidden" example:"public"`
+
+	// Anti-cheat rules; zero disables a rule
+	MaxSubmissions   int     `json:"max_submissions,omitempty" example:"10"`
+	SubmissionWindow int     `json:"submission_window_seconds,omitempty" example:"60"`
+	MaxGainPerHour   int64   `json:"max_gain_per_hour,omitempty" example:"50000"`
+	OutlierZScore    float64 `json:"outlier_z_score,omitempty" example:"4"`
+	FlagZScore       float64 `json:"flag_z_score,omitempty" example:"3"`
+}
+
+func (c GameConfigDTO) toDomain() domain.GameConfig {
+	return domain.GameConfig{
+		MinScore:    c.MinScore,
+		MaxScore:    c.MaxScore,
+		Aggregation: c.Aggregation,
+		Visibility:  c.Visibility,
+
+		MaxSubmissions:   c.MaxSubmissions,
+		SubmissionWindow: c.SubmissionWindow,
+		MaxGainPerHour:   c.MaxGainPerHour,
+		OutlierZScore:    c.OutlierZScore,
+		FlagZScore:       c.FlagZScore,
+	}
 }
 
 // GameDTO represents game information
 type GameDTO struct {
-	ID   string `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
-	Name string `json:"name" example:"Chess"`
+	ID          string        `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Name        string        `json:"name" example:"Chess"`
+	Slug        string        `json:"slug" example:"chess"`
+	Description string        `json:"description" example:"Classic chess, rated games only"`
+	Config      GameConfigDTO `json:"config"`
+	Signed      bool          `json:"signed" example:"false"`
+	Archived    bool          `json:"archived" example:"false"`
+	ArchivedAt  *time.Time    `json:"archived_at,omitempty"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
+
+// SigningSecretResponse represents a newly generated signing secret; it is only shown once
+type SigningSecretResponse struct {
+	Secret string `json:"secret" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
 }
 
 // GamesResponse represents games list response
@@ -43,6 +123,152 @@ type GamesResponse struct {
 	Data []GameDTO `json:"data"`
 }
 
+// QuarantinedScoreDTO represents a submission held for review
+type QuarantinedScoreDTO struct {
+	ID        string    `json:"id" example:"5f0c2a4e-8d3b-4d1e-9a59-2c7c1c0f6b11"`
+	UserID    string    `json:"user_id" example:"01234567-89ab-cdef-0123-456789abcdef"`
+	GameID    string    `json:"game_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Score     int       `json:"score" example:"2147483647"`
+	Rule      string    `json:"rule" enums:"score_bounds,submission_rate,hourly_gain,outlier" example:"score_bounds"`
+	Reason    string    `json:"reason" example:"score 2147483647 is outside [0, 100000]"`
+	Status    string    `json:"status" enums:"pending,released,discarded" example:"pending"`
+	ScoreID   string    `json:"score_id,omitempty" example:"9b2f1c1e-8a47-4c55-a8c0-6c3a1f1b7d21"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// QuarantineResponse represents a page of quarantined submissions
+type QuarantineResponse struct {
+	Data []QuarantinedScoreDTO `json:"data"`
+}
+
+// ScoreFlagDTO represents a moderation flag on a score
+type ScoreFlagDTO struct {
+	ID          string     `json:"id" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	ScoreID     string     `json:"score_id" example:"3fa85f64-5717-4562-b3fc-2c963f66afa6"`
+	UserID      string     `json:"user_id" example:"01234567-89ab-cdef-0123-456789abcdef"`
+	GameID      string     `json:"game_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Score       int        `json:"score" example:"99999"`
+	ReporterID  string     `json:"reporter_id,omitempty" example:"89abcdef-0123-4567-89ab-cdef01234567"`
+	Source      string     `json:"source" enums:"player,rule" example:"player"`
+	Reason      string     `json:"reason" example:"impossible time on level 3"`
+	Status      string     `json:"status" enums:"pending,approved,rejected" example:"pending"`
+	ModeratorID string     `json:"moderator_id,omitempty" example:"fedcba98-7654-3210-fedc-ba9876543210"`
+	DecidedAt   *time.Time `json:"decided_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// FlagsResponse represents a page of moderation flags
+type FlagsResponse struct {
+	Data []ScoreFlagDTO `json:"data"`
+}
+
+// FlagIDResponse represents flag creation response
+type FlagIDResponse struct {
+	FlagID string `json:"flag_id" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+}
+
+// BanDTO represents a user ban
+type BanDTO struct {
+	ID        string     `json:"id" example:"0b3c6f3e-2d4a-4b8e-9a3f-6c1d2e3f4a5b"`
+	UserID    string     `json:"user_id" example:"01234567-89ab-cdef-0123-456789abcdef"`
+	Scopes    []string   `json:"scopes" enums:"login,submit,visibility" example:"submit,visibility"`
+	Reason    string     `json:"reason" example:"confirmed cheating"`
+	Active    bool       `json:"active" example:"true"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedBy string     `json:"created_by,omitempty" example:"fedcba98-7654-3210-fedc-ba9876543210"`
+	CreatedAt time.Time  `json:"created_at"`
+	LiftedAt  *time.Time `json:"lifted_at,omitempty"`
+	LiftedBy  string     `json:"lifted_by,omitempty" example:"fedcba98-7654-3210-fedc-ba9876543210"`
+}
+
+// BansResponse represents a user's bans
+type BansResponse struct {
+	Data []BanDTO `json:"data"`
+}
+
+// BanIDResponse represents ban creation response
+type BanIDResponse struct {
+	BanID string `json:"ban_id" example:"0b3c6f3e-2d4a-4b8e-9a3f-6c1d2e3f4a5b"`
+}
+
+// AuditEntryDTO represents an audit log entry; actor_id and target_id are empty when unknown
+type AuditEntryDTO struct {
+	ID         string         `json:"id" example:"5f1e2d3c-4b5a-6978-8a9b-0c1d2e3f4a5b"`
+	ActorID    string         `json:"actor_id,omitempty" example:"fedcba98-7654-3210-fedc-ba9876543210"`
+	Action     string         `json:"action" example:"game.update"`
+	TargetType string         `json:"target_type" example:"game"`
+	TargetID   string         `json:"target_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	RequestID  string         `json:"request_id,omitempty" example:"8c0e3a52-3f7d-4b8e-a1c2-9d4e5f6a7b8c"`
+	IP         string         `json:"ip,omitempty" example:"203.0.113.7"`
+	Before     any            `json:"before,omitempty" swaggertype:"object"`
+	After      any            `json:"after,omitempty" swaggertype:"object"`
+	Details    map[string]any `json:"details,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+// AuditResponse represents a page of the audit log
+type AuditResponse struct {
+	Data       []AuditEntryDTO `json:"data"`
+	NextCursor string          `json:"next_cursor,omitempty" example:"MTcwMDAwMDAwMDAwMDAwMDo1ZjFlMmQzYw"`
+}
+
+// ScoreRecordDTO represents a score history entry
+type ScoreRecordDTO struct {
+	ID        string    `json:"id" example:"0b3c6f3e-2d4a-4b8e-9a3f-6c1d2e3f4a5b"`
+	GameID    string    `json:"game_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Score     int       `json:"score" example:"1500"`
+	Kind      string    `json:"kind" enums:"submission,adjustment" example:"submission"`
+	Status    string    `json:"status" enums:"accepted,rejected,voided" example:"accepted"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ScoreSummaryDTO represents aggregates of a user's accepted scores for a game
+type ScoreSummaryDTO struct {
+	GameID  string  `json:"game_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Best    int64   `json:"best" example:"2400"`
+	Average float64 `json:"average" example:"1312.5"`
+	Count   int64   `json:"count" example:"16"`
+	Total   int64   `json:"total" example:"21000"`
+}
+
+// ScoreHistoryResponse represents a page of the user's score history with per-game aggregates
+type ScoreHistoryResponse struct {
+	Data       []ScoreRecordDTO  `json:"data"`
+	Summary    []ScoreSummaryDTO `json:"summary"`
+	NextCursor string            `json:"next_cursor,omitempty" example:"MTcwMDAwMDAwMDAwMDAwMDowYjNjNmYzZQ"`
+}
+
+// ProgressPointDTO represents a player's rank and score over one time bucket
+type ProgressPointDTO struct {
+	At        time.Time `json:"at"`
+	BestRank  int64     `json:"best_rank" example:"3"`
+	Rank      int64     `json:"rank" example:"5"`
+	BestScore int64     `json:"best_score" example:"2400"`
+	Score     int64     `json:"score" example:"2400"`
+}
+
+// ProgressResponse represents a player's progress on a game leaderboard, oldest bucket first
+type ProgressResponse struct {
+	UserID string             `json:"user_id" example:"01234567-89ab-cdef-0123-456789abcdef"`
+	GameID string             `json:"game_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Bucket string             `json:"bucket" enums:"hour,day,week" example:"day"`
+	Data   []ProgressPointDTO `json:"data"`
+}
+
+// HealthCheckDTO represents the outcome of one readiness check
+type HealthCheckDTO struct {
+	Name       string  `json:"name" enums:"shutdown,postgres,redis,migrations" example:"postgres"`
+	Status     string  `json:"status" enums:"ok,fail" example:"ok"`
+	Error      string  `json:"error,omitempty" example:"dial tcp 10.0.0.5:6379: connect: connection refused"`
+	DurationMs float64 `json:"duration_ms" example:"1.8"`
+}
+
+// HealthResponse represents the readiness of the instance and the detail of every check
+type HealthResponse struct {
+	Status string           `json:"status" enums:"ok,fail" example:"ok"`
+	Checks []HealthCheckDTO `json:"checks"`
+}
+
 // RegisterResponse represents registration response
 type RegisterResponse struct {
 	UserID string `json:"user_id" example:"01234567-89ab-cdef-0123-456789abcdef"`
@@ -59,7 +285,230 @@ type GameIDResponse struct {
 	GameID string `json:"game_id" example:"123e4567-e89b-12d3-a456-426614174000"`
 }
 
+func newLeaderboardResponse(page domain.LeaderboardPage) LeaderboardResponse {
+	// Convert domain models to DTOs (uuid.UUID -> string)
+	userDTOs := make([]LeaderboardUserDTO, 0, len(page.Users))
+	for _, user := range page.Users {
+		userDTOs = append(userDTOs, LeaderboardUserDTO{
+			UserID: user.UserID.String(),
+			Score:  user.Score,
+			Rank:   user.Rank,
+		})
+	}
+	return LeaderboardResponse{
+		Data:       userDTOs,
+		NextCursor: page.NextCursor,
+		Total:      page.Total,
+	}
+}
+
+func newGameDTO(game domain.Game) GameDTO {
+	return GameDTO{
+		ID:          game.Id.String(),
+		Name:        game.Name,
+		Slug:        game.Slug,
+		Description: game.Description,
+		Config: GameConfigDTO{
+			MinScore:    game.Config.MinScore,
+			MaxScore:    game.Config.MaxScore,
+			Aggregation: game.Config.Aggregation,
+			Visibility:  game.Config.Visibility,
+
+			MaxSubmissions:   game.Config.MaxSubmissions,
+			SubmissionWindow: game.Config.SubmissionWindow,
+			MaxGainPerHour:   game.Config.MaxGainPerHour,
+			OutlierZScore:    game.Config.OutlierZScore,
+			FlagZScore:       game.Config.FlagZScore,
+		},
+		Signed:     game.Signed(),
+		Archived:   game.Archived(),
+		ArchivedAt: game.ArchivedAt,
+		CreatedAt:  game.CreatedAt,
+		UpdatedAt:  game.UpdatedAt,
+	}
+}
+
+func newGamesResponse(games []domain.Game) GamesResponse {
+	// Convert domain models to DTOs (uuid.UUID -> string)
+	gameDTOs := make([]GameDTO, 0, len(games))
+	for _, game := range games {
+		gameDTOs = append(gameDTOs, newGameDTO(game))
+	}
+	return GamesResponse{Data: gameDTOs}
+}
+
+func newRankResponse(rank domain.PlayerRank) RankResponse {
+	resp := RankResponse{
+		Rank:       rank.Overall.Rank,
+		Total:      rank.Overall.Total,
+		Percentile: rank.Overall.Percentile(),
+		TopPercent: rank.Overall.TopPercent(),
+	}
+	if rank.Region != "" {
+		resp.Region = rank.Region
+		resp.RegionalRank = rank.Regional.Rank
+		resp.RegionalTotal = rank.Regional.Total
+		resp.RegionalPercentile = rank.Regional.Percentile()
+		resp.RegionalTopPercent = rank.Regional.TopPercent()
+	}
+	return resp
+}
+
 func NewErrorResponse(c *gin.Context, statusCode int, message string) {
 	slog.Error(message)
 	c.AbortWithStatusJSON(statusCode, ErrorResponse{Message: message})
 }
+
+func newQuarantineResponse(scores []domain.QuarantinedScore) QuarantineResponse {
+	data := make([]QuarantinedScoreDTO, 0, len(scores))
+	for _, score := range scores {
+		dto := QuarantinedScoreDTO{
+			ID:        score.Id.String(),
+			UserID:    score.UserID.String(),
+			GameID:    score.GameID.String(),
+			Score:     score.Score,
+			Rule:      score.Rule,
+			Reason:    score.Reason,
+			Status:    score.Status,
+			CreatedAt: score.CreatedAt,
+		}
+		if score.ScoreID != nil {
+			dto.ScoreID = score.ScoreID.String()
+		}
+		data = append(data, dto)
+	}
+	return QuarantineResponse{Data: data}
+}
+
+func newFlagsResponse(flags []domain.ScoreFlag) FlagsResponse {
+	data := make([]ScoreFlagDTO, 0, len(flags))
+	for _, flag := range flags {
+		dto := ScoreFlagDTO{
+			ID:        flag.Id.String(),
+			ScoreID:   flag.ScoreID.String(),
+			UserID:    flag.UserID.String(),
+			GameID:    flag.GameID.String(),
+			Score:     flag.Score,
+			Source:    flag.Source,
+			Reason:    flag.Reason,
+			Status:    flag.Status,
+			DecidedAt: flag.DecidedAt,
+			CreatedAt: flag.CreatedAt,
+		}
+		if flag.ReporterID != nil {
+			dto.ReporterID = flag.ReporterID.String()
+		}
+		if flag.ModeratorID != nil {
+			dto.ModeratorID = flag.ModeratorID.String()
+		}
+		data = append(data, dto)
+	}
+	return FlagsResponse{Data: data}
+}
+
+func newBansResponse(bans []domain.Ban) BansResponse {
+	now := time.Now()
+	data := make([]BanDTO, 0, len(bans))
+	for _, ban := range bans {
+		dto := BanDTO{
+			ID:        ban.Id.String(),
+			UserID:    ban.UserID.String(),
+			Scopes:    ban.Scopes,
+			Reason:    ban.Reason,
+			Active:    ban.Active(now),
+			ExpiresAt: ban.ExpiresAt,
+			CreatedAt: ban.CreatedAt,
+			LiftedAt:  ban.LiftedAt,
+		}
+		if ban.CreatedBy != nil {
+			dto.CreatedBy = ban.CreatedBy.String()
+		}
+		if ban.LiftedBy != nil {
+			dto.LiftedBy = ban.LiftedBy.String()
+		}
+		data = append(data, dto)
+	}
+	return BansResponse{Data: data}
+}
+
+func newAuditResponse(page domain.AuditPage) AuditResponse {
+	data := make([]AuditEntryDTO, 0, len(page.Entries))
+	for _, entry := range page.Entries {
+		dto := AuditEntryDTO{
+			ID:         entry.Id.String(),
+			Action:     entry.Action,
+			TargetType: entry.TargetType,
+			RequestID:  entry.RequestID,
+			IP:         entry.IP,
+			Before:     entry.Before,
+			After:      entry.After,
+			Details:    entry.Details,
+			CreatedAt:  entry.CreatedAt,
+		}
+		if entry.ActorID != uuid.Nil {
+			dto.ActorID = entry.ActorID.String()
+		}
+		if entry.TargetID != uuid.Nil {
+			dto.TargetID = entry.TargetID.String()
+		}
+		data = append(data, dto)
+	}
+	return AuditResponse{Data: data, NextCursor: page.NextCursor}
+}
+
+func newScoreHistoryResponse(page domain.ScoreHistoryPage) ScoreHistoryResponse {
+	data := make([]ScoreRecordDTO, 0, len(page.Scores))
+	for _, score := range page.Scores {
+		data = append(data, ScoreRecordDTO{
+			ID:        score.Id.String(),
+			GameID:    score.GameID.String(),
+			Score:     score.Score,
+			Kind:      score.Kind,
+			Status:    score.Status,
+			CreatedAt: score.CreatedAt,
+		})
+	}
+	summary := make([]ScoreSummaryDTO, 0, len(page.Summary))
+	for _, s := range page.Summary {
+		summary = append(summary, ScoreSummaryDTO{
+			GameID:  s.GameID.String(),
+			Best:    s.Best,
+			Average: s.Average,
+			Count:   s.Count,
+			Total:   s.Total,
+		})
+	}
+	return ScoreHistoryResponse{Data: data, Summary: summary, NextCursor: page.NextCursor}
+}
+
+func newProgressResponse(series domain.ProgressSeries) ProgressResponse {
+	data := make([]ProgressPointDTO, 0, len(series.Points))
+	for _, p := range series.Points {
+		data = append(data, ProgressPointDTO{
+			At:        p.At,
+			BestRank:  p.BestRank,
+			Rank:      p.Rank,
+			BestScore: p.BestScore,
+			Score:     p.Score,
+		})
+	}
+	return ProgressResponse{
+		UserID: series.UserID.String(),
+		GameID: series.GameID.String(),
+		Bucket: series.Bucket,
+		Data:   data,
+	}
+}
+
+func newHealthResponse(report domain.HealthReport) HealthResponse {
+	checks := make([]HealthCheckDTO, 0, len(report.Checks))
+	for _, check := range report.Checks {
+		checks = append(checks, HealthCheckDTO{
+			Name:       check.Name,
+			Status:     check.Status,
+			Error:      check.Error,
+			DurationMs: float64(check.Duration.Microseconds()) / 1000,
+		})
+	}
+	return HealthResponse{Status: report.Status, Checks: checks}
+}
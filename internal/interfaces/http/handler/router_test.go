@@ -0,0 +1,150 @@
+package handler_test
+
+import (
+	"OnlineLeadership/internal/domain"
+	"OnlineLeadership/internal/infrastructure/logger"
+	"OnlineLeadership/internal/interfaces/http/handler"
+	"OnlineLeadership/internal/usecase"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Access tokens accepted by fakeAuth, one per role.
+const (
+	playerToken    = "player-token"
+	moderatorToken = "moderator-token"
+	adminToken     = "admin-token"
+)
+
+var roleTokens = map[string]string{
+	playerToken:    domain.RolePlayer,
+	moderatorToken: domain.RoleModerator,
+	adminToken:     domain.RoleAdmin,
+}
+
+// Each fake user's id is derived from its role, so that fakeProfile can look it up.
+func roleUserID(role string) uuid.UUID {
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(role))
+}
+
+type fakeAuth struct{ usecase.Auth }
+
+func (fakeAuth) ParseAccessToken(_ context.Context, token string) (uuid.UUID, error) {
+	role, ok := roleTokens[token]
+	if !ok {
+		return uuid.Nil, errors.New("invalid token")
+	}
+	return roleUserID(role), nil
+}
+
+type fakeBans struct{ usecase.Bans }
+
+func (fakeBans) CheckBan(context.Context, uuid.UUID, string) error {
+	return nil
+}
+
+type fakeProfile struct{ usecase.Profile }
+
+func (fakeProfile) GetProfile(_ context.Context, userID uuid.UUID) (domain.User, error) {
+	for _, role := range roleTokens {
+		if roleUserID(role) == userID {
+			return domain.User{Id: userID, Role: role}, nil
+		}
+	}
+	return domain.User{}, domain.ErrUserNotFound
+}
+
+// newRouter returns the router over a service that only authenticates: any
+// handler reached past the middleware panics on the missing usecase and
+// answers 500, so a rejection status proves the route is gated.
+func newRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	gin.DefaultWriter = io.Discard
+	gin.DefaultErrorWriter = io.Discard
+	service := &usecase.Service{Auth: fakeAuth{}, Bans: fakeBans{}, Profile: fakeProfile{}}
+	return handler.NewHandler(service, logger.New("error", logger.FormatText), nil).InitRouter()
+}
+
+type route struct {
+	method, path string
+}
+
+// testAdminOnly checks that each route rejects anonymous callers and every
+// role but admin, and lets admins through to the handler.
+func testAdminOnly(t *testing.T, routes []route) {
+	router := newRouter()
+	tests := []struct {
+		name  string
+		token string
+		want  int
+	}{
+		{"anonymous", "", http.StatusBadRequest},
+		{"invalid token", "forged", http.StatusUnauthorized},
+		{"player", playerToken, http.StatusForbidden},
+		{"moderator", moderatorToken, http.StatusForbidden},
+	}
+	for _, r := range routes {
+		for _, tt := range tests {
+			t.Run(r.method+" "+r.path+"/"+tt.name, func(t *testing.T) {
+				req := httptest.NewRequest(r.method, r.path, nil)
+				if tt.token != "" {
+					req.Header.Set("Authorization", "Bearer "+tt.token)
+				}
+				rec := httptest.NewRecorder()
+				router.ServeHTTP(rec, req)
+				if rec.Code != tt.want {
+					t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+				}
+			})
+		}
+		t.Run(r.method+" "+r.path+"/admin", func(t *testing.T) {
+			req := httptest.NewRequest(r.method, r.path, nil)
+			req.Header.Set("Authorization", "Bearer "+adminToken)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			if rec.Code == http.StatusUnauthorized || rec.Code == http.StatusForbidden || rec.Code == http.StatusNotFound {
+				t.Fatalf("status = %d, want the route to let admins through", rec.Code)
+			}
+		})
+	}
+}
+
+func TestGameRoutesRequireAdmin(t *testing.T) {
+	id := uuid.NewString()
+	testAdminOnly(t, []route{
+		{http.MethodPut, "/admin/games/" + id},
+		{http.MethodDelete, "/admin/games/" + id},
+		{http.MethodPost, "/admin/games/" + id + "/archive"},
+		{http.MethodPost, "/admin/games/" + id + "/restore"},
+	})
+}
+
+func TestQuarantineRoutesRequireAdmin(t *testing.T) {
+	id := uuid.NewString()
+	testAdminOnly(t, []route{
+		{http.MethodGet, "/admin/quarantine"},
+		{http.MethodPost, "/admin/quarantine/" + id + "/release"},
+		{http.MethodPost, "/admin/quarantine/" + id + "/discard"},
+	})
+}
+
+func TestSigningRoutesRequireAdmin(t *testing.T) {
+	id := uuid.NewString()
+	testAdminOnly(t, []route{
+		{http.MethodPost, "/admin/games/" + id + "/secret"},
+		{http.MethodDelete, "/admin/games/" + id + "/secret"},
+	})
+}
+
+func TestAuditRouteRequiresAdmin(t *testing.T) {
+	testAdminOnly(t, []route{
+		{http.MethodGet, "/admin/audit"},
+	})
+}
@@ -1,20 +1,27 @@
 package handler
 
 import (
+	"OnlineLeadership/internal/domain"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
-// SubmitScoreInput represents score submission payload
+// SubmitScoreInput represents score submission payload.
+// Nonce, timestamp and signature are required for games with a signing secret:
+// signature is the hex HMAC-SHA256 of "user_id|game_id|score|nonce|timestamp".
 type SubmitScoreInput struct {
-	GameID string `json:"game_id" binding:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
-	Score  int    `json:"score" binding:"required,min=0" example:"12345"`
+	GameID    string `json:"game_id" binding:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Score     int    `json:"score" binding:"required,min=0" example:"12345"`
+	Nonce     string `json:"nonce" binding:"max=64" example:"c0a8f3e2b1d94e7f"`
+	Timestamp int64  `json:"timestamp" example:"1760000000"`
+	Signature string `json:"signature" binding:"max=128" example:"5d41402abc4b2a76b9719d911017c592..."`
 }
 
 // @Summary Submit player's score
-// @Description Submit a user's score for a game
+// @Description Submit a user's score for a game. Submissions breaking one of the game's anti-cheat rules are held for review and answered with 422. Games with a signing secret require a signed payload with a fresh timestamp and an unused nonce
 // @Tags score
 // @Accept json
 // @Produce json
@@ -23,6 +30,10 @@ type SubmitScoreInput struct {
 // @Success 200 {object} StatusResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/score/submit [post]
 func (h *Handler) submitScore(c *gin.Context) {
@@ -45,7 +56,34 @@ func (h *Handler) submitScore(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.SubmitScore(c.Request.Context(), userID, gameID, req.Score); err != nil {
+	err = h.service.SubmitScore(c.Request.Context(), domain.ScoreSubmission{
+		UserID:    userID,
+		GameID:    gameID,
+		Score:     req.Score,
+		Nonce:     req.Nonce,
+		Timestamp: req.Timestamp,
+		Signature: req.Signature,
+	})
+	switch {
+	case errors.Is(err, domain.ErrGameNotFound):
+		NewErrorResponse(c, http.StatusNotFound, err.Error())
+		return
+	case errors.Is(err, domain.ErrGameArchived):
+		NewErrorResponse(c, http.StatusConflict, err.Error())
+		return
+	case errors.Is(err, domain.ErrUserBanned), errors.Is(err, domain.ErrInvalidSignature):
+		NewErrorResponse(c, http.StatusForbidden, err.Error())
+		return
+	case errors.Is(err, domain.ErrStaleSubmission):
+		NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	case errors.Is(err, domain.ErrNonceReused):
+		NewErrorResponse(c, http.StatusConflict, err.Error())
+		return
+	case errors.Is(err, domain.ErrScoreQuarantined):
+		NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		return
+	case err != nil:
 		NewErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -0,0 +1,11 @@
+package middleware
+
+import "context"
+
+// ClientIPKey holds the client address resolved by the router, honouring its trusted proxies.
+const ClientIPKey ctxKey = "client_ip"
+
+// WithClientIP returns a copy of ctx carrying the client address.
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, ClientIPKey, ip)
+}
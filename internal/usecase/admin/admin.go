@@ -4,23 +4,382 @@ import (
 	"OnlineLeadership/internal/domain"
 	"OnlineLeadership/internal/infrastructure/logger"
 	"OnlineLeadership/internal/infrastructure/repository"
+	"OnlineLeadership/internal/usecase/games"
 	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
 	"github.com/google/uuid"
+	"strings"
+)
+
+// signingSecretSize is the length in bytes of generated signing secrets.
+const signingSecretSize = 32
+
+// rebuildPageSize is the number of board rows read per page while a rebuild
+// looks for players to remove.
+const rebuildPageSize = 1000
+
+const (
+	DefaultAuditPageSize = 50
+	MaxAuditPageSize     = 100
 )
 
 type ServiceAdmin struct {
-	rep repository.Admin
-	log *logger.SlogLogger
+	rep      repository.Admin
+	board    repository.LeaderBoard
+	audit    repository.Audit
+	users    repository.Auth
+	scores   repository.ScoreHistory
+	registry *games.Registry
+	log      *logger.SlogLogger
 }
 
-func NewServiceAdmin(repo repository.Admin, log *logger.SlogLogger) *ServiceAdmin {
-	return &ServiceAdmin{rep: repo, log: log}
+func NewServiceAdmin(repo repository.Admin, board repository.LeaderBoard, audit repository.Audit, users repository.Auth, scores repository.ScoreHistory, registry *games.Registry, log *logger.SlogLogger) *ServiceAdmin {
+	return &ServiceAdmin{rep: repo, board: board, audit: audit, users: users, scores: scores, registry: registry, log: log}
 }
 
-func (s *ServiceAdmin) Create(ctx context.Context, name string) (uuid.UUID, error) {
-	return s.rep.Create(ctx, name)
+func (s *ServiceAdmin) Create(ctx context.Context, actorID uuid.UUID, game domain.Game) (uuid.UUID, error) {
+	game, err := normalizeGame(game)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	id, err := s.rep.Create(ctx, game)
+	if err != nil {
+		s.log.Error(ctx, "repo create game error", err.Error())
+		return uuid.UUID{}, err
+	}
+	s.registry.Invalidate()
+	s.log.Info(ctx, "service create game passed", "game_id", id, "actor_id", actorID)
+	game.Id = id
+	return id, s.record(ctx, actorID, domain.AuditGameCreate, id, nil, game)
 }
 
+// GetGames returns every game, archived and hidden ones included.
 func (s *ServiceAdmin) GetGames(ctx context.Context) ([]domain.Game, error) {
 	return s.rep.GetGames(ctx)
 }
+
+// ListGames returns the games shown to players: active and public.
+func (s *ServiceAdmin) ListGames(ctx context.Context) ([]domain.Game, error) {
+	games, err := s.rep.GetGames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	visible := make([]domain.Game, 0, len(games))
+	for _, game := range games {
+		if game.Archived() || game.Config.WithDefaults().Visibility != domain.VisibilityPublic {
+			continue
+		}
+		visible = append(visible, game)
+	}
+	return visible, nil
+}
+
+func (s *ServiceAdmin) GetGame(ctx context.Context, id uuid.UUID) (domain.Game, error) {
+	return s.rep.GetGame(ctx, id)
+}
+
+// UpdateGame replaces the game's name, slug, description and configuration.
+// The aggregation cannot change once the game has accepted scores, since the
+// board would keep the scores aggregated the old way.
+func (s *ServiceAdmin) UpdateGame(ctx context.Context, actorID uuid.UUID, game domain.Game) error {
+	game, err := normalizeGame(game)
+	if err != nil {
+		return err
+	}
+	before, err := s.rep.GetGame(ctx, game.Id)
+	if err != nil {
+		return err
+	}
+	if before.Config.WithDefaults().Aggregation != game.Config.WithDefaults().Aggregation {
+		scored, err := s.scores.HasGameScores(ctx, game.Id)
+		if err != nil {
+			s.log.Error(ctx, "repo has game scores error", err.Error(), "game_id", game.Id)
+			return err
+		}
+		if scored {
+			return domain.ErrAggregationLocked
+		}
+	}
+	if err := s.rep.UpdateGame(ctx, game); err != nil {
+		s.log.Error(ctx, "repo update game error", err.Error())
+		return err
+	}
+	s.registry.Invalidate()
+	s.log.Info(ctx, "service update game passed", "game_id", game.Id, "actor_id", actorID)
+	return s.recordChange(ctx, actorID, domain.AuditGameUpdate, before)
+}
+
+func (s *ServiceAdmin) ArchiveGame(ctx context.Context, actorID uuid.UUID, id uuid.UUID) error {
+	return s.setArchived(ctx, actorID, id, true)
+}
+
+func (s *ServiceAdmin) RestoreGame(ctx context.Context, actorID uuid.UUID, id uuid.UUID) error {
+	return s.setArchived(ctx, actorID, id, false)
+}
+
+func (s *ServiceAdmin) setArchived(ctx context.Context, actorID uuid.UUID, id uuid.UUID, archived bool) error {
+	action, op := domain.AuditGameRestore, "restore"
+	if archived {
+		action, op = domain.AuditGameArchive, "archive"
+	}
+	before, err := s.rep.GetGame(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.rep.SetArchived(ctx, id, archived); err != nil {
+		s.log.Error(ctx, "repo "+op+" game error", err.Error())
+		return err
+	}
+	s.registry.Invalidate()
+	s.log.Info(ctx, "service "+op+" game passed", "game_id", id, "actor_id", actorID)
+	return s.recordChange(ctx, actorID, action, before)
+}
+
+// RotateSigningSecret generates a new signing secret for the game and returns it.
+// Submissions signed with the previous secret are rejected from then on.
+func (s *ServiceAdmin) RotateSigningSecret(ctx context.Context, actorID uuid.UUID, id uuid.UUID) (string, error) {
+	before, err := s.rep.GetGame(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	key := make([]byte, signingSecretSize)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	secret := hex.EncodeToString(key)
+	if err := s.rep.SetSigningSecret(ctx, id, secret); err != nil {
+		s.log.Error(ctx, "repo set signing secret error", err.Error())
+		return "", err
+	}
+	s.registry.Invalidate()
+	s.log.Info(ctx, "service rotate signing secret passed", "game_id", id, "actor_id", actorID)
+	// The secret itself is never written to the audit log.
+	return secret, s.record(ctx, actorID, domain.AuditGameRotateSecret, id, signing(before.Signed()), signing(true))
+}
+
+// DisableSigning removes the game's signing secret so that it accepts unsigned submissions again.
+func (s *ServiceAdmin) DisableSigning(ctx context.Context, actorID uuid.UUID, id uuid.UUID) error {
+	before, err := s.rep.GetGame(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.rep.SetSigningSecret(ctx, id, ""); err != nil {
+		s.log.Error(ctx, "repo clear signing secret error", err.Error())
+		return err
+	}
+	s.registry.Invalidate()
+	s.log.Info(ctx, "service disable signing passed", "game_id", id, "actor_id", actorID)
+	return s.record(ctx, actorID, domain.AuditGameDisableSecret, id, signing(before.Signed()), signing(false))
+}
+
+// DeleteGame removes the game's leaderboards and its contribution to the
+// global boards before deleting the game and, by cascade, its score history.
+// The game is archived first, so submissions stop reaching the boards while
+// they are purged, on other replicas once their registry refreshes. A deletion
+// that failed half-way can be retried.
+func (s *ServiceAdmin) DeleteGame(ctx context.Context, actorID uuid.UUID, id uuid.UUID) error {
+	before, err := s.rep.GetGame(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.rep.SetArchived(ctx, id, true); err != nil {
+		s.log.Error(ctx, "repo archive game error", err.Error())
+		return err
+	}
+	s.registry.Invalidate()
+	if err := s.board.PurgeGame(ctx, id); err != nil {
+		s.log.Error(ctx, "repo purge game leaderboards error", err.Error())
+		return err
+	}
+	if err := s.rep.DeleteGame(ctx, id); err != nil {
+		s.log.Error(ctx, "repo delete game error", err.Error())
+		return err
+	}
+	s.registry.Invalidate()
+	s.log.Info(ctx, "service delete game passed", "game_id", id, "actor_id", actorID)
+	return s.record(ctx, actorID, domain.AuditGameDelete, id, before, nil)
+}
+
+// RebuildLeaderboard recomputes the game's boards from the accepted score
+// history and returns the number of players placed. Every player's game score
+// is replaced in place, the change carried over to the global boards, and the
+// players the history no longer accounts for are then removed, so the boards
+// stay readable throughout and a rebuild that failed half-way can be run
+// again. Players hidden by a visibility ban stay off the boards. A score
+// submitted while its player is being replaced may be overwritten, so run it
+// during low traffic.
+func (s *ServiceAdmin) RebuildLeaderboard(ctx context.Context, actorID uuid.UUID, id uuid.UUID) (int, error) {
+	game, err := s.rep.GetGame(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	totals, err := s.scores.GameTotals(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	aggregation := game.Config.WithDefaults().Aggregation
+	placed := make(map[uuid.UUID]bool, len(totals))
+	for _, total := range totals {
+		score := total.Sum
+		if aggregation == domain.AggregationMax {
+			score = total.Best
+		}
+		change, err := s.board.ReplaceGameScore(ctx, id.String(), total.UserID.String(), total.Region, score, true)
+		if err != nil {
+			s.log.Error(ctx, "repo replace game score error", err.Error(), "user_id", total.UserID)
+			return 0, err
+		}
+		if change != 0 {
+			if err := s.board.IncrementGlobalScore(ctx, total.UserID.String(), total.Region, int(change)); err != nil {
+				s.log.Error(ctx, "repo increment global score error", err.Error(), "user_id", total.UserID)
+				return 0, err
+			}
+		}
+		placed[total.UserID] = true
+	}
+
+	stale, err := s.unplacedMembers(ctx, id, placed)
+	if err != nil {
+		return 0, err
+	}
+	for _, userID := range stale {
+		// The region of a deleted user is unknown; their overall entries are still removed.
+		var region string
+		user, err := s.users.GetUserByID(ctx, userID)
+		if err == nil {
+			region = user.Region
+		} else if !errors.Is(err, domain.ErrUserNotFound) {
+			return 0, err
+		}
+		if err := s.board.RemoveMember(ctx, []uuid.UUID{id}, userID.String(), region, false); err != nil {
+			s.log.Error(ctx, "repo remove member error", err.Error(), "user_id", userID)
+			return 0, err
+		}
+	}
+	s.log.Info(ctx, "service rebuild leaderboard passed", "game_id", id, "players", len(totals), "removed", len(stale), "actor_id", actorID)
+	return len(totals), s.record(ctx, actorID, domain.AuditGameRebuildBoard, id, nil, map[string]any{
+		"players": len(totals),
+		"removed": len(stale),
+	})
+}
+
+// unplacedMembers returns the players on the game board that are not in placed.
+func (s *ServiceAdmin) unplacedMembers(ctx context.Context, id uuid.UUID, placed map[uuid.UUID]bool) ([]uuid.UUID, error) {
+	var stale []uuid.UUID
+	page := domain.PageRequest{Limit: rebuildPageSize}
+	for {
+		rows, err := s.board.GetLeaderboard(ctx, id, "", page)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows.Users {
+			if !placed[row.UserID] {
+				stale = append(stale, row.UserID)
+			}
+		}
+		if rows.NextCursor == "" {
+			return stale, nil
+		}
+		cursor, err := domain.DecodeCursor(rows.NextCursor)
+		if err != nil {
+			return nil, err
+		}
+		page.Cursor = &cursor
+	}
+}
+
+// FindUser looks a user up by username.
+func (s *ServiceAdmin) FindUser(ctx context.Context, username string) (domain.User, error) {
+	user, err := s.users.GetUserByUsername(ctx, username)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.User{}, domain.ErrUserNotFound
+	}
+	if err != nil {
+		return domain.User{}, err
+	}
+	return s.users.GetUserByID(ctx, user.Id)
+}
+
+// SetRole grants the user the given role, replacing their current one.
+func (s *ServiceAdmin) SetRole(ctx context.Context, actorID uuid.UUID, userID uuid.UUID, role string) error {
+	if !domain.ValidRole(role) {
+		return domain.ErrInvalidRole
+	}
+	previous, err := s.users.SetRole(ctx, userID, role)
+	if err != nil {
+		return err
+	}
+	s.log.Info(ctx, "service set role passed", "user_id", userID, "role", role, "actor_id", actorID)
+	return s.audit.Record(ctx, domain.AuditEntry{
+		ActorID:    actorID,
+		Action:     domain.AuditUserSetRole,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   userID,
+		Before:     map[string]any{"role": previous},
+		After:      map[string]any{"role": role},
+	})
+}
+
+// ListAudit returns a page of audit entries matching the filter, newest first.
+func (s *ServiceAdmin) ListAudit(ctx context.Context, filter domain.AuditFilter) (domain.AuditPage, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultAuditPageSize
+	}
+	filter.Limit = min(filter.Limit, MaxAuditPageSize)
+	entries, err := s.audit.List(ctx, filter)
+	if err != nil {
+		return domain.AuditPage{}, err
+	}
+	page := domain.AuditPage{Entries: entries}
+	if len(entries) > filter.Limit {
+		page.Entries = entries[:filter.Limit]
+		last := page.Entries[filter.Limit-1]
+		page.NextCursor = domain.TimeCursor(last.CreatedAt, last.Id).Encode()
+	}
+	return page, nil
+}
+
+// recordChange audits a change of the game, reading its state after the change.
+func (s *ServiceAdmin) recordChange(ctx context.Context, actorID uuid.UUID, action string, before domain.Game) error {
+	after, err := s.rep.GetGame(ctx, before.Id)
+	if err != nil {
+		return err
+	}
+	return s.record(ctx, actorID, action, before.Id, before, after)
+}
+
+func (s *ServiceAdmin) record(ctx context.Context, actorID uuid.UUID, action string, gameID uuid.UUID, before, after any) error {
+	return s.audit.Record(ctx, domain.AuditEntry{
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: domain.AuditTargetGame,
+		TargetID:   gameID,
+		Before:     before,
+		After:      after,
+	})
+}
+
+// signing describes whether a game requires signed submissions without exposing its secret.
+func signing(signed bool) map[string]any {
+	return map[string]any{"signed": signed}
+}
+
+func normalizeGame(game domain.Game) (domain.Game, error) {
+	game.Name = strings.TrimSpace(game.Name)
+	game.Description = strings.TrimSpace(game.Description)
+	if game.Slug == "" {
+		game.Slug = game.Name
+	}
+	game.Slug = domain.Slugify(game.Slug)
+	if game.Slug == "" {
+		return domain.Game{}, errors.Join(domain.ErrInvalidGame, errors.New("slug must contain letters or digits"))
+	}
+	if err := game.Config.Validate(); err != nil {
+		return domain.Game{}, err
+	}
+	game.Config = game.Config.WithDefaults()
+	return game, nil
+}
@@ -0,0 +1,96 @@
+package admin
+
+import (
+	"OnlineLeadership/internal/domain"
+	"OnlineLeadership/internal/infrastructure/logger"
+	"OnlineLeadership/internal/infrastructure/repository"
+	"OnlineLeadership/internal/usecase/games"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// gameStore keeps a single game in memory.
+type gameStore struct {
+	repository.Admin
+	game    domain.Game
+	updated bool
+}
+
+func (f *gameStore) GetGame(_ context.Context, id uuid.UUID) (domain.Game, error) {
+	if id != f.game.Id {
+		return domain.Game{}, domain.ErrGameNotFound
+	}
+	return f.game, nil
+}
+
+func (f *gameStore) UpdateGame(_ context.Context, game domain.Game) error {
+	f.game, f.updated = game, true
+	return nil
+}
+
+type scoredHistory struct {
+	repository.ScoreHistory
+	scored bool
+}
+
+func (f scoredHistory) HasGameScores(context.Context, uuid.UUID) (bool, error) {
+	return f.scored, nil
+}
+
+type discardAudit struct{ repository.Audit }
+
+func (discardAudit) Record(context.Context, domain.AuditEntry) error {
+	return nil
+}
+
+func TestUpdateGameAggregation(t *testing.T) {
+	tests := []struct {
+		name    string
+		scored  bool
+		update  func(game *domain.Game)
+		wantErr error
+	}{
+		{
+			name:    "aggregation change with scores",
+			scored:  true,
+			update:  func(game *domain.Game) { game.Config.Aggregation = domain.AggregationMax },
+			wantErr: domain.ErrAggregationLocked,
+		},
+		{
+			name:   "aggregation change without scores",
+			update: func(game *domain.Game) { game.Config.Aggregation = domain.AggregationMax },
+		},
+		{
+			name:   "rename with scores",
+			scored: true,
+			update: func(game *domain.Game) { game.Name = "Renamed" },
+		},
+		{
+			name:   "unset aggregation with scores keeps the default",
+			scored: true,
+			update: func(game *domain.Game) { game.Config.Aggregation = "" },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := logger.New("error", logger.FormatText)
+			game := domain.Game{Id: uuid.New(), Name: "Chess", Slug: "chess", Config: domain.GameConfig{Aggregation: domain.AggregationSum}.WithDefaults()}
+			store := &gameStore{game: game}
+			service := NewServiceAdmin(store, nil, discardAudit{}, nil, scoredHistory{scored: tt.scored}, games.NewRegistry(store, log, time.Hour), log)
+
+			tt.update(&game)
+			err := service.UpdateGame(context.Background(), uuid.New(), game)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateGame error = %v, want %v", err, tt.wantErr)
+			}
+			if store.updated != (tt.wantErr == nil) {
+				t.Errorf("game updated = %v, want %v", store.updated, tt.wantErr == nil)
+			}
+		})
+	}
+}
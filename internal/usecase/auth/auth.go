@@ -4,6 +4,7 @@ import (
 	"OnlineLeadership/internal/domain"
 	"OnlineLeadership/internal/infrastructure/logger"
 	"OnlineLeadership/internal/infrastructure/repository"
+	"OnlineLeadership/internal/usecase/bans"
 	"context"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
@@ -18,38 +19,60 @@ type TokenManager interface {
 
 type ServiceAuth struct {
 	repo   repository.Auth
+	audit  repository.Audit
 	log    *logger.SlogLogger
 	tokens TokenManager
+	bans   *bans.Checker
 }
 
-func NewServiceAuth(repo repository.Auth, log *logger.SlogLogger, tokens TokenManager) *ServiceAuth {
+func NewServiceAuth(repo repository.Auth, audit repository.Audit, log *logger.SlogLogger, tokens TokenManager, bans *bans.Checker) *ServiceAuth {
 	return &ServiceAuth{
 		repo:   repo,
+		audit:  audit,
 		log:    log,
 		tokens: tokens,
+		bans:   bans,
 	}
 }
 
 func (s *ServiceAuth) Register(ctx context.Context, user domain.User) (uuid.UUID, error) {
+	region, err := domain.NormalizeRegion(user.Region)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	user.Region = region
+
 	hash, err := hashPassword(user.Password)
 	if err != nil {
 		s.log.Error(ctx, "service auth: hash password error", err.Error())
 		return uuid.UUID{}, err
 	}
 	user.Password = hash
-	return s.repo.CreateUser(ctx, user)
+	id, err := s.repo.CreateUser(ctx, user)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	s.record(ctx, id, domain.AuditRegister, id, map[string]any{"username": user.Username, "region": user.Region})
+	return id, nil
 }
 
 func (s *ServiceAuth) Login(ctx context.Context, username, password string) (string, string, error) {
 	user, err := s.repo.GetUserByUsername(ctx, username)
 	if err != nil {
 		s.log.Error(ctx, "repo auth: get user error", err.Error())
+		s.record(ctx, uuid.Nil, domain.AuditLoginFailed, uuid.Nil, map[string]any{"username": username, "reason": "unknown user"})
 		return "", "", err
 	}
 
 	if err := checkPassword(password, user.Password); err != nil {
 		s.log.Error(ctx, "repo auth: check password error", err.Error())
+		s.record(ctx, uuid.Nil, domain.AuditLoginFailed, user.Id, map[string]any{"username": username, "reason": "invalid password"})
+		return "", "", err
+	}
 
+	if err := s.bans.Check(ctx, user.Id, domain.BanScopeLogin); err != nil {
+		s.log.Warn(ctx, "service auth: login of banned user", "user_id", user.Id)
+		s.record(ctx, user.Id, domain.AuditLoginFailed, user.Id, map[string]any{"username": username, "reason": "banned"})
 		return "", "", err
 	}
 
@@ -67,9 +90,25 @@ func (s *ServiceAuth) Login(ctx context.Context, username, password string) (str
 
 	}
 
+	s.record(ctx, user.Id, domain.AuditLogin, user.Id, nil)
 	return access, refresh, nil
 }
 
+// record writes an authentication event to the audit log. A failure is only
+// logged: an unavailable audit log must not lock users out.
+func (s *ServiceAuth) record(ctx context.Context, actorID uuid.UUID, action string, userID uuid.UUID, details map[string]any) {
+	err := s.audit.Record(ctx, domain.AuditEntry{
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   userID,
+		Details:    details,
+	})
+	if err != nil {
+		s.log.Error(ctx, "service auth: record audit entry error", err.Error(), "action", action)
+	}
+}
+
 func (s *ServiceAuth) ParseAccessToken(ctx context.Context, token string) (uuid.UUID, error) {
 	userIdStr, err := s.tokens.ParseAccessToken(ctx, token)
 	if err != nil {
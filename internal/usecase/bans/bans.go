@@ -0,0 +1,210 @@
+package bans
+
+import (
+	"OnlineLeadership/internal/domain"
+	"OnlineLeadership/internal/infrastructure/logger"
+	"OnlineLeadership/internal/infrastructure/repository"
+	"OnlineLeadership/internal/usecase/games"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ServiceBans struct {
+	repo    *repository.Repository
+	checker *Checker
+	games   *games.Registry
+	log     *logger.SlogLogger
+}
+
+func NewServiceBans(repo *repository.Repository, checker *Checker, registry *games.Registry, log *logger.SlogLogger) *ServiceBans {
+	return &ServiceBans{repo: repo, checker: checker, games: registry, log: log}
+}
+
+// CheckBan returns an error wrapping domain.ErrUserBanned when a ban forbids the scope.
+func (s *ServiceBans) CheckBan(ctx context.Context, userID uuid.UUID, scope string) error {
+	return s.checker.Check(ctx, userID, scope)
+}
+
+// Ban suspends the user for the given scopes, permanently when duration is zero.
+// A visibility ban takes the user off every leaderboard; their scores stay in Postgres.
+func (s *ServiceBans) Ban(ctx context.Context, actorID uuid.UUID, userID uuid.UUID, scopes []string, reason string, duration time.Duration) (uuid.UUID, error) {
+	ban := domain.Ban{
+		UserID:    userID,
+		Scopes:    scopes,
+		Reason:    strings.TrimSpace(reason),
+		CreatedAt: time.Now(),
+	}
+	// Bans issued from the admin CLI without --actor have no author.
+	if actorID != uuid.Nil {
+		ban.CreatedBy = &actorID
+	}
+	if duration > 0 {
+		expiresAt := ban.CreatedAt.Add(duration)
+		ban.ExpiresAt = &expiresAt
+	}
+	if err := ban.Validate(); err != nil {
+		return uuid.UUID{}, err
+	}
+	user, err := s.repo.Auth.GetUserByID(ctx, userID)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	id, err := s.repo.Bans.Create(ctx, ban)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	s.checker.Invalidate()
+	s.log.Info(ctx, "user banned", "ban_id", id, "user_id", userID, "scopes", scopes, "actor_id", actorID)
+
+	if ban.Covers(domain.BanScopeVisibility) {
+		if err := s.hide(ctx, user); err != nil {
+			s.log.Error(ctx, "hide banned user error", err.Error(), "user_id", userID)
+			return uuid.UUID{}, err
+		}
+	}
+
+	ban.Id = id
+	details := map[string]any{"ban_id": id, "scopes": scopes, "reason": ban.Reason}
+	if ban.ExpiresAt != nil {
+		details["expires_at"] = ban.ExpiresAt.UTC()
+	}
+	return id, s.audit(ctx, actorID, domain.AuditUserBan, userID, nil, ban, details)
+}
+
+// ListBans returns every ban of the user, lifted and expired ones included.
+func (s *ServiceBans) ListBans(ctx context.Context, userID uuid.UUID) ([]domain.Ban, error) {
+	return s.repo.Bans.ListByUser(ctx, userID)
+}
+
+// LiftBan reinstates the user. Lifting a visibility ban rebuilds the user's
+// leaderboard entries from the score history unless another visibility ban is in force.
+func (s *ServiceBans) LiftBan(ctx context.Context, actorID uuid.UUID, banID uuid.UUID) error {
+	ban, err := s.repo.Bans.Lift(ctx, banID, &actorID, time.Now())
+	if err != nil {
+		return err
+	}
+	s.checker.Invalidate()
+	s.log.Info(ctx, "ban lifted", "ban_id", banID, "user_id", ban.UserID, "actor_id", actorID)
+
+	if err := s.reinstate(ctx, ban); err != nil {
+		return err
+	}
+	before := ban
+	before.LiftedAt, before.LiftedBy = nil, nil
+	return s.audit(ctx, actorID, domain.AuditUserUnban, ban.UserID, before, ban, map[string]any{"ban_id": banID})
+}
+
+// LiftExpired lifts the bans that have expired and reinstates their users. It
+// returns how many bans were lifted.
+func (s *ServiceBans) LiftExpired(ctx context.Context) (int, error) {
+	expired, err := s.repo.Bans.ListExpired(ctx)
+	if err != nil {
+		return 0, err
+	}
+	lifted := 0
+	for _, ban := range expired {
+		ban, err := s.repo.Bans.Lift(ctx, ban.Id, nil, *ban.ExpiresAt)
+		if err != nil {
+			// Lifted by an admin or another replica in the meantime.
+			continue
+		}
+		s.checker.Invalidate()
+		if err := s.reinstate(ctx, ban); err != nil {
+			return lifted, err
+		}
+		lifted++
+	}
+	return lifted, nil
+}
+
+// RunExpirySweeper lifts expired bans every interval until ctx is done.
+func (s *ServiceBans) RunExpirySweeper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			lifted, err := s.LiftExpired(ctx)
+			if err != nil {
+				s.log.Error(ctx, "ban expiry sweep error", err.Error())
+			}
+			if lifted > 0 {
+				s.log.Info(ctx, "expired bans lifted", "count", lifted)
+			}
+		}
+	}
+}
+
+// reinstate restores the visibility of a user whose visibility ban was lifted.
+func (s *ServiceBans) reinstate(ctx context.Context, ban domain.Ban) error {
+	if !ban.Covers(domain.BanScopeVisibility) {
+		return nil
+	}
+	hidden, err := s.checker.Hidden(ctx, ban.UserID)
+	if err != nil || hidden {
+		return err
+	}
+	user, err := s.repo.Auth.GetUserByID(ctx, ban.UserID)
+	if err != nil {
+		return err
+	}
+	if err := s.restore(ctx, user); err != nil {
+		s.log.Error(ctx, "restore reinstated user error", err.Error(), "user_id", user.Id)
+		return err
+	}
+	return nil
+}
+
+// hide takes the user off every game board and the global boards.
+func (s *ServiceBans) hide(ctx context.Context, user domain.User) error {
+	gameIDs, err := s.repo.ScoreHistory.GetUserGames(ctx, user.Id)
+	if err != nil {
+		return err
+	}
+	return s.repo.LeaderBoard.RemoveMember(ctx, gameIDs, user.Id.String(), user.Region, true)
+}
+
+// restore rebuilds the user's game and global scores from their accepted score history.
+func (s *ServiceBans) restore(ctx context.Context, user domain.User) error {
+	totals, err := s.repo.ScoreHistory.UserGameTotals(ctx, user.Id)
+	if err != nil {
+		return err
+	}
+	var global int64
+	for _, total := range totals {
+		game, err := s.games.Get(ctx, total.GameID)
+		if err != nil {
+			return err
+		}
+		score := total.Sum
+		if game.Config.WithDefaults().Aggregation == domain.AggregationMax {
+			score = total.Best
+		}
+		if _, err := s.repo.LeaderBoard.ReplaceGameScore(ctx, total.GameID.String(), user.Id.String(), user.Region, score, true); err != nil {
+			return err
+		}
+		global += score
+	}
+	if len(totals) == 0 {
+		return nil
+	}
+	return s.repo.LeaderBoard.SetGlobalScore(ctx, user.Id.String(), user.Region, global)
+}
+
+func (s *ServiceBans) audit(ctx context.Context, actorID uuid.UUID, action string, userID uuid.UUID, before, after any, details map[string]any) error {
+	return s.repo.Audit.Record(ctx, domain.AuditEntry{
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   userID,
+		Before:     before,
+		After:      after,
+		Details:    details,
+	})
+}
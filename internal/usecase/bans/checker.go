@@ -0,0 +1,99 @@
+package bans
+
+import (
+	"OnlineLeadership/internal/domain"
+	"OnlineLeadership/internal/infrastructure/logger"
+	"OnlineLeadership/internal/infrastructure/repository"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Checker is an in-memory cache of the active bans consulted on every
+// authenticated request and score submission. Active bans are few, so the
+// whole set is reloaded after ttl and on the next lookup after Invalidate.
+type Checker struct {
+	repo repository.Bans
+	log  *logger.SlogLogger
+	ttl  time.Duration
+
+	mu       sync.RWMutex
+	bans     map[uuid.UUID][]domain.Ban
+	loadedAt time.Time
+}
+
+func NewChecker(repo repository.Bans, log *logger.SlogLogger, ttl time.Duration) *Checker {
+	return &Checker{repo: repo, log: log, ttl: ttl}
+}
+
+// Active returns the user's ban covering the scope, if any. Expired bans are
+// ignored even before the sweeper lifts them.
+func (c *Checker) Active(ctx context.Context, userID uuid.UUID, scope string) (domain.Ban, bool, error) {
+	c.mu.RLock()
+	bans, fresh := c.bans, c.bans != nil && time.Since(c.loadedAt) < c.ttl
+	c.mu.RUnlock()
+	if !fresh {
+		var err error
+		if bans, err = c.reload(ctx); err != nil {
+			return domain.Ban{}, false, err
+		}
+	}
+
+	now := time.Now()
+	for _, ban := range bans[userID] {
+		if ban.Active(now) && ban.Covers(scope) {
+			return ban, true, nil
+		}
+	}
+	return domain.Ban{}, false, nil
+}
+
+// Check returns an error wrapping domain.ErrUserBanned when a ban forbids the scope.
+func (c *Checker) Check(ctx context.Context, userID uuid.UUID, scope string) error {
+	ban, banned, err := c.Active(ctx, userID, scope)
+	if err != nil {
+		return err
+	}
+	if banned {
+		return ban.Err()
+	}
+	return nil
+}
+
+// Hidden reports whether the user is hidden from the leaderboards.
+func (c *Checker) Hidden(ctx context.Context, userID uuid.UUID) (bool, error) {
+	_, hidden, err := c.Active(ctx, userID, domain.BanScopeVisibility)
+	return hidden, err
+}
+
+// Invalidate drops the cached bans; the next lookup reloads them.
+func (c *Checker) Invalidate() {
+	c.mu.Lock()
+	c.bans = nil
+	c.loadedAt = time.Time{}
+	c.mu.Unlock()
+}
+
+func (c *Checker) reload(ctx context.Context) (map[uuid.UUID][]domain.Ban, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Another goroutine may have reloaded while this one waited for the lock.
+	if c.bans != nil && time.Since(c.loadedAt) < c.ttl {
+		return c.bans, nil
+	}
+
+	list, err := c.repo.ListActive(ctx)
+	if err != nil {
+		c.log.Error(ctx, "ban cache reload error", err.Error())
+		return nil, err
+	}
+	bans := make(map[uuid.UUID][]domain.Ban)
+	for _, ban := range list {
+		bans[ban.UserID] = append(bans[ban.UserID], ban)
+	}
+	c.bans = bans
+	c.loadedAt = time.Now()
+	return bans, nil
+}
@@ -0,0 +1,102 @@
+package games
+
+import (
+	"OnlineLeadership/internal/domain"
+	"OnlineLeadership/internal/infrastructure/logger"
+	"OnlineLeadership/internal/infrastructure/repository"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// missReloadInterval limits how often a lookup of an unknown id reloads the
+// table, so that requests with random ids cannot hammer Postgres.
+const missReloadInterval = time.Second
+
+// Registry is an in-memory cache of the games table consulted on hot paths
+// such as score submission. It is reloaded after ttl, on a miss, and on the
+// next lookup after Invalidate; admin use cases invalidate it on every change.
+type Registry struct {
+	repo repository.Admin
+	log  *logger.SlogLogger
+	ttl  time.Duration
+
+	mu       sync.RWMutex
+	games    map[uuid.UUID]domain.Game
+	loadedAt time.Time
+}
+
+func NewRegistry(repo repository.Admin, log *logger.SlogLogger, ttl time.Duration) *Registry {
+	return &Registry{repo: repo, log: log, ttl: ttl}
+}
+
+// Get returns the game with the given id, archived or not.
+func (r *Registry) Get(ctx context.Context, id uuid.UUID) (domain.Game, error) {
+	r.mu.RLock()
+	game, ok := r.games[id]
+	fresh := r.games != nil && time.Since(r.loadedAt) < r.ttl
+	recent := time.Since(r.loadedAt) < missReloadInterval
+	r.mu.RUnlock()
+
+	if ok && fresh {
+		return game, nil
+	}
+	if !ok && fresh && recent {
+		return domain.Game{}, domain.ErrGameNotFound
+	}
+
+	if err := r.reload(ctx); err != nil {
+		return domain.Game{}, err
+	}
+	r.mu.RLock()
+	game, ok = r.games[id]
+	r.mu.RUnlock()
+	if !ok {
+		return domain.Game{}, domain.ErrGameNotFound
+	}
+	return game, nil
+}
+
+// Active returns the game only if it accepts new scores.
+func (r *Registry) Active(ctx context.Context, id uuid.UUID) (domain.Game, error) {
+	game, err := r.Get(ctx, id)
+	if err != nil {
+		return domain.Game{}, err
+	}
+	if game.Archived() {
+		return domain.Game{}, domain.ErrGameArchived
+	}
+	return game, nil
+}
+
+// Invalidate drops the cached games; the next lookup reloads them.
+func (r *Registry) Invalidate() {
+	r.mu.Lock()
+	r.games = nil
+	r.loadedAt = time.Time{}
+	r.mu.Unlock()
+}
+
+func (r *Registry) reload(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// Another goroutine may have reloaded while this one waited for the lock.
+	if r.games != nil && time.Since(r.loadedAt) < missReloadInterval {
+		return nil
+	}
+
+	list, err := r.repo.GetGames(ctx)
+	if err != nil {
+		r.log.Error(ctx, "game registry reload error", err.Error())
+		return err
+	}
+	games := make(map[uuid.UUID]domain.Game, len(list))
+	for _, game := range list {
+		games[game.Id] = game
+	}
+	r.games = games
+	r.loadedAt = time.Now()
+	return nil
+}
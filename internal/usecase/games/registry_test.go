@@ -0,0 +1,96 @@
+package games
+
+import (
+	"OnlineLeadership/internal/domain"
+	"OnlineLeadership/internal/infrastructure/logger"
+	"OnlineLeadership/internal/infrastructure/repository"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fakeGames serves a fixed games table and counts how often it is read.
+type fakeGames struct {
+	repository.Admin
+	games []domain.Game
+	loads int
+}
+
+func (f *fakeGames) GetGames(context.Context) ([]domain.Game, error) {
+	f.loads++
+	return f.games, nil
+}
+
+func newTestRegistry(repo *fakeGames) *Registry {
+	return NewRegistry(repo, logger.New("error", logger.FormatText), time.Hour)
+}
+
+func TestRegistryLookups(t *testing.T) {
+	archivedAt := time.Now()
+	active := domain.Game{Id: uuid.New(), Slug: "active"}
+	archived := domain.Game{Id: uuid.New(), Slug: "archived", ArchivedAt: &archivedAt}
+	registry := newTestRegistry(&fakeGames{games: []domain.Game{active, archived}})
+
+	tests := []struct {
+		name   string
+		lookup func(context.Context, uuid.UUID) (domain.Game, error)
+		id     uuid.UUID
+		want   string
+		err    error
+	}{
+		{"get active", registry.Get, active.Id, "active", nil},
+		{"get archived", registry.Get, archived.Id, "archived", nil},
+		{"get unknown", registry.Get, uuid.New(), "", domain.ErrGameNotFound},
+		{"active game", registry.Active, active.Id, "active", nil},
+		{"archived game", registry.Active, archived.Id, "", domain.ErrGameArchived},
+		{"unknown game", registry.Active, uuid.New(), "", domain.ErrGameNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, err := tt.lookup(context.Background(), tt.id)
+			if !errors.Is(err, tt.err) || game.Slug != tt.want {
+				t.Fatalf("lookup = %q, %v; want %q, %v", game.Slug, err, tt.want, tt.err)
+			}
+		})
+	}
+}
+
+func TestRegistryReloads(t *testing.T) {
+	ctx := context.Background()
+	game := domain.Game{Id: uuid.New(), Slug: "first"}
+	repo := &fakeGames{games: []domain.Game{game}}
+	registry := newTestRegistry(repo)
+
+	for range 3 {
+		if _, err := registry.Get(ctx, game.Id); err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+	}
+	if repo.loads != 1 {
+		t.Fatalf("loads = %d after cached lookups, want 1", repo.loads)
+	}
+
+	// Misses right after a load are answered from the cache.
+	for range 3 {
+		if _, err := registry.Get(ctx, uuid.New()); !errors.Is(err, domain.ErrGameNotFound) {
+			t.Fatalf("Get(unknown) = %v, want %v", err, domain.ErrGameNotFound)
+		}
+	}
+	if repo.loads != 1 {
+		t.Fatalf("loads = %d after repeated misses, want 1", repo.loads)
+	}
+
+	// Invalidate makes the next lookup see the change.
+	repo.games = []domain.Game{{Id: game.Id, Slug: "renamed"}}
+	registry.Invalidate()
+	got, err := registry.Get(ctx, game.Id)
+	if err != nil || got.Slug != "renamed" {
+		t.Fatalf("Get after Invalidate = %q, %v; want %q", got.Slug, err, "renamed")
+	}
+	if repo.loads != 2 {
+		t.Fatalf("loads = %d after Invalidate, want 2", repo.loads)
+	}
+}
@@ -0,0 +1,109 @@
+package health
+
+import (
+	"OnlineLeadership/internal/domain"
+	"OnlineLeadership/internal/infrastructure/logger"
+	"OnlineLeadership/internal/infrastructure/repository"
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CheckTimeout bounds every readiness check so that a hung dependency fails
+// the probe instead of stalling it.
+const CheckTimeout = 2 * time.Second
+
+type ServiceHealth struct {
+	repo repository.Health
+	// schemaVersion is the migration the code expects the database to be at.
+	schemaVersion uint
+	draining      atomic.Bool
+	log           *logger.SlogLogger
+}
+
+func NewServiceHealth(repo repository.Health, schemaVersion uint, log *logger.SlogLogger) *ServiceHealth {
+	return &ServiceHealth{repo: repo, schemaVersion: schemaVersion, log: log}
+}
+
+// Drain makes the instance report not ready from now on, so that the
+// orchestrator stops routing traffic to it before it shuts down.
+func (s *ServiceHealth) Drain() {
+	s.draining.Store(true)
+}
+
+type readinessCheck struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// Ready runs the dependency checks concurrently and reports every outcome.
+// Redis is only checked when the service was started with it.
+// There is no outbox lag check: boards are updated within the request that
+// records a score, so there is no queue to fall behind.
+func (s *ServiceHealth) Ready(ctx context.Context) domain.HealthReport {
+	checks := []readinessCheck{
+		{domain.CheckShutdown, s.checkShutdown},
+		{domain.CheckPostgres, s.repo.PingPostgres},
+	}
+	if s.repo.RedisConfigured() {
+		checks = append(checks, readinessCheck{domain.CheckRedis, s.repo.PingRedis})
+	}
+	checks = append(checks, readinessCheck{domain.CheckMigrations, s.checkMigrations})
+
+	report := domain.HealthReport{
+		Status: domain.HealthOK,
+		Checks: make([]domain.HealthCheck, len(checks)),
+	}
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, CheckTimeout)
+			defer cancel()
+			start := time.Now()
+			err := check.run(checkCtx)
+			result := domain.HealthCheck{Name: check.name, Status: domain.HealthOK, Duration: time.Since(start)}
+			if err != nil {
+				result.Status = domain.HealthFail
+				result.Error = err.Error()
+			}
+			report.Checks[i] = result
+		}()
+	}
+	wg.Wait()
+
+	for _, check := range report.Checks {
+		if check.Status != domain.HealthOK {
+			report.Status = domain.HealthFail
+			s.log.Warn(ctx, "readiness check failed", "check", check.Name, "error", check.Error)
+		}
+	}
+	return report
+}
+
+func (s *ServiceHealth) checkShutdown(context.Context) error {
+	if s.draining.Load() {
+		return domain.ErrShuttingDown
+	}
+	return nil
+}
+
+// checkMigrations fails while the schema is dirty or behind the code. A schema
+// ahead of the code is fine: during a rolling deploy the new release migrates
+// while the old one is still serving.
+func (s *ServiceHealth) checkMigrations(ctx context.Context) error {
+	version, dirty, err := s.repo.SchemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("migration %d is dirty", version)
+	}
+	if version < s.schemaVersion {
+		return fmt.Errorf("schema is at version %d, expected %d", version, s.schemaVersion)
+	}
+	return nil
+}
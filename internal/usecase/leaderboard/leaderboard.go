@@ -8,43 +8,154 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 100
+
+	DefaultDistributionBuckets = 10
+	MaxDistributionBuckets     = 50
+)
+
 type ServiceLeaderboard struct {
-	repo repository.LeaderBoard
-	log  *logger.SlogLogger
+	repo  repository.LeaderBoard
+	users repository.Auth
+	games repository.Admin
+	log   *logger.SlogLogger
 }
 
-func NewServiceLeaderboard(repo repository.LeaderBoard, log *logger.SlogLogger) *ServiceLeaderboard {
+func NewServiceLeaderboard(repo repository.LeaderBoard, users repository.Auth, games repository.Admin, log *logger.SlogLogger) *ServiceLeaderboard {
 	return &ServiceLeaderboard{
-		repo: repo,
-		log:  log,
+		repo:  repo,
+		users: users,
+		games: games,
+		log:   log,
 	}
 }
 
-func (s *ServiceLeaderboard) GetGlobalLeaderboard(ctx context.Context, offset int, limit int) ([]domain.LeaderboardUser, error) {
-	users, err := s.repo.GetGlobal(ctx, offset, limit)
+func (s *ServiceLeaderboard) GetGlobalLeaderboard(ctx context.Context, region string, page domain.PageRequest) (domain.LeaderboardPage, error) {
+	region, err := domain.NormalizeRegion(region)
+	if err != nil {
+		return domain.LeaderboardPage{}, err
+	}
+	users, err := s.repo.GetGlobal(ctx, region, clampPage(page))
 	if err != nil {
 		s.log.Error(ctx, "get global leaderboard error", err.Error())
-		return nil, err
+		return domain.LeaderboardPage{}, err
 	}
 	s.log.Info(ctx, "get global service ")
 	return users, nil
 }
-func (s *ServiceLeaderboard) GetMyRank(ctx context.Context, userID uuid.UUID) (int64, error) {
-	rank, err := s.repo.GetMyRank(ctx, userID)
+func (s *ServiceLeaderboard) GetMyRank(ctx context.Context, userID uuid.UUID) (domain.PlayerRank, error) {
+	rank, err := s.playerRank(ctx, userID, func(region string) (domain.RankStats, error) {
+		return s.repo.GetMyRank(ctx, userID, region)
+	})
 	if err != nil {
 		s.log.Error(ctx, "repo get my rank error ", err.Error())
-		return 0, err
+		return domain.PlayerRank{}, err
 	}
 	s.log.Info(ctx, "service get my rank passed")
 	return rank, nil
 }
 
-func (s *ServiceLeaderboard) GetLeaderboard(ctx context.Context, gameID uuid.UUID) ([]domain.LeaderboardUser, error) {
-	users, err := s.repo.GetLeaderboard(ctx, gameID)
+func (s *ServiceLeaderboard) GetGameRank(ctx context.Context, userID uuid.UUID, gameID uuid.UUID) (domain.PlayerRank, error) {
+	rank, err := s.playerRank(ctx, userID, func(region string) (domain.RankStats, error) {
+		return s.repo.GetGameRank(ctx, gameID, userID, region)
+	})
+	if err != nil {
+		s.log.Error(ctx, "repo get game rank error ", err.Error())
+		return domain.PlayerRank{}, err
+	}
+	s.log.Info(ctx, "service get game rank passed")
+	return rank, nil
+}
+
+// playerRank looks the player up on a board and on its regional variant.
+func (s *ServiceLeaderboard) playerRank(ctx context.Context, userID uuid.UUID, lookup func(region string) (domain.RankStats, error)) (domain.PlayerRank, error) {
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		return domain.PlayerRank{}, err
+	}
+
+	overall, err := lookup("")
+	if err != nil {
+		return domain.PlayerRank{}, err
+	}
+	result := domain.PlayerRank{Overall: overall}
+
+	if user.Region != "" {
+		regional, err := lookup(user.Region)
+		if err != nil {
+			return domain.PlayerRank{}, err
+		}
+		result.Region = user.Region
+		result.Regional = regional
+	}
+	return result, nil
+}
+
+func (s *ServiceLeaderboard) GetDistribution(ctx context.Context, gameID uuid.UUID, region string, buckets int) (domain.ScoreDistribution, error) {
+	region, err := domain.NormalizeRegion(region)
+	if err != nil {
+		return domain.ScoreDistribution{}, err
+	}
+	if buckets <= 0 {
+		buckets = DefaultDistributionBuckets
+	}
+	buckets = min(buckets, MaxDistributionBuckets)
+
+	dist, err := s.repo.GetDistribution(ctx, gameID, region, buckets)
+	if err != nil {
+		s.log.Error(ctx, "repo get distribution error", err.Error())
+		return domain.ScoreDistribution{}, err
+	}
+	return dist, nil
+}
+
+func (s *ServiceLeaderboard) GetLeaderboard(ctx context.Context, gameID uuid.UUID, region string, page domain.PageRequest) (domain.LeaderboardPage, error) {
+	region, err := domain.NormalizeRegion(region)
+	if err != nil {
+		return domain.LeaderboardPage{}, err
+	}
+	users, err := s.repo.GetLeaderboard(ctx, gameID, region, clampPage(page))
 	if err != nil {
 		s.log.Error(ctx, "repo get leaderboard error", err.Error())
-		return []domain.LeaderboardUser{}, err
+		return domain.LeaderboardPage{}, err
 	}
 	s.log.Info(ctx, "service get leaderboard passed")
 	return users, nil
 }
+
+func (s *ServiceLeaderboard) GetLeaderboardVersion(ctx context.Context, gameID uuid.UUID) (domain.BoardVersion, error) {
+	version, err := s.repo.GetBoardVersion(ctx, gameID)
+	if err != nil {
+		s.log.Error(ctx, "repo get board version error", err.Error())
+		return domain.BoardVersion{}, err
+	}
+	return version, nil
+}
+
+// clampPage applies the default page size and caps it at MaxPageSize for every board.
+func clampPage(page domain.PageRequest) domain.PageRequest {
+	if page.Limit <= 0 {
+		page.Limit = DefaultPageSize
+	}
+	page.Limit = min(page.Limit, MaxPageSize)
+	page.Offset = max(page.Offset, 0)
+	return page
+}
+
+// BoardSizes returns the number of players on the global board and on the board
+// of every active game, keyed by "global" and the game id.
+func (s *ServiceLeaderboard) BoardSizes(ctx context.Context) (map[string]int64, error) {
+	games, err := s.games.GetGames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uuid.UUID, 0, len(games))
+	for _, game := range games {
+		if !game.Archived() {
+			ids = append(ids, game.Id)
+		}
+	}
+	return s.repo.BoardSizes(ctx, ids)
+}
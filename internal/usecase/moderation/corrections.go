@@ -0,0 +1,136 @@
+package moderation
+
+import (
+	"OnlineLeadership/internal/domain"
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// VoidScore removes a score history entry from the leaderboards on behalf of an
+// admin. Voiding a score that is already void repeats the board update, so a
+// void that failed half-way can be retried, and still reports ErrScoreVoided.
+func (s *ServiceModeration) VoidScore(ctx context.Context, actorID uuid.UUID, scoreID uuid.UUID, reason string) error {
+	score, err := s.repo.ScoreHistory.Void(ctx, scoreID)
+	if errors.Is(err, domain.ErrScoreVoided) {
+		return s.retryRemoval(ctx, scoreID, err)
+	}
+	if err != nil {
+		return err
+	}
+	if err := s.removeContribution(ctx, score); err != nil {
+		s.log.Error(ctx, "remove voided score from leaderboards error", err.Error(), "score_id", scoreID)
+		return err
+	}
+	s.log.Info(ctx, "score voided", "score_id", scoreID, "actor_id", actorID)
+	before := score
+	before.Status = domain.ScoreAccepted
+	return s.audit(ctx, actorID, domain.AuditScoreVoid, domain.AuditTargetScore, scoreID, before, score, map[string]any{
+		"user_id": score.UserID,
+		"game_id": score.GameID,
+		"score":   score.Score,
+		"reason":  strings.TrimSpace(reason),
+	})
+}
+
+// AdjustScore adds delta to the user's score on a game board and, through it,
+// on the global boards. The adjustment is kept in the score history so that it
+// can be voided like any other entry. Games keeping the best submission cannot
+// be adjusted; their scores are corrected by voiding submissions.
+func (s *ServiceModeration) AdjustScore(ctx context.Context, actorID uuid.UUID, gameID uuid.UUID, userID uuid.UUID, delta int, reason string) error {
+	game, err := s.games.Get(ctx, gameID)
+	if err != nil {
+		return err
+	}
+	if game.Config.WithDefaults().Aggregation != domain.AggregationSum {
+		return domain.ErrAdjustmentUnsupported
+	}
+	user, err := s.repo.Auth.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	scoreID, err := s.repo.ScoreHistory.SaveAdjustment(ctx, userID, gameID, delta)
+	if err != nil {
+		return err
+	}
+	hidden, err := s.bans.Hidden(ctx, userID)
+	if err != nil {
+		return err
+	}
+	// Hidden users get the adjustment when their visibility ban is lifted.
+	if !hidden {
+		if err := s.repo.LeaderBoard.IncrementGameScore(ctx, gameID.String(), userID.String(), user.Region, delta); err != nil {
+			return err
+		}
+		if err := s.repo.LeaderBoard.IncrementGlobalScore(ctx, userID.String(), user.Region, delta); err != nil {
+			return err
+		}
+	}
+	s.log.Info(ctx, "score adjusted", "score_id", scoreID, "user_id", userID, "game_id", gameID, "delta", delta, "actor_id", actorID)
+	after := domain.ScoreRecord{
+		Id:     scoreID,
+		UserID: userID,
+		GameID: gameID,
+		Score:  delta,
+		Kind:   domain.ScoreKindAdjustment,
+		Status: domain.ScoreAccepted,
+	}
+	return s.audit(ctx, actorID, domain.AuditScoreAdjust, domain.AuditTargetScore, scoreID, nil, after, map[string]any{
+		"user_id": userID,
+		"game_id": gameID,
+		"delta":   delta,
+		"reason":  strings.TrimSpace(reason),
+	})
+}
+
+// RemoveUser voids the user's scores for a game, or for every game when gameID
+// is nil, and takes the user off the matching leaderboards.
+func (s *ServiceModeration) RemoveUser(ctx context.Context, actorID uuid.UUID, userID uuid.UUID, gameID *uuid.UUID, reason string) error {
+	user, err := s.repo.Auth.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	var gameIDs []uuid.UUID
+	if gameID != nil {
+		if _, err := s.games.Get(ctx, *gameID); err != nil {
+			return err
+		}
+		gameIDs = []uuid.UUID{*gameID}
+	} else if gameIDs, err = s.repo.ScoreHistory.GetUserGames(ctx, userID); err != nil {
+		return err
+	}
+
+	voided, err := s.repo.ScoreHistory.VoidUserScores(ctx, userID, gameID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.LeaderBoard.RemoveMember(ctx, gameIDs, userID.String(), user.Region, gameID == nil); err != nil {
+		s.log.Error(ctx, "remove user from leaderboards error", err.Error(), "user_id", userID)
+		return err
+	}
+	s.log.Info(ctx, "user removed from leaderboards", "user_id", userID, "games", len(gameIDs), "actor_id", actorID)
+
+	details := map[string]any{
+		"voided_scores": voided,
+		"reason":        strings.TrimSpace(reason),
+	}
+	if gameID != nil {
+		details["game_id"] = *gameID
+	}
+	return s.audit(ctx, actorID, domain.AuditUserRemove, domain.AuditTargetUser, userID, nil, nil, details)
+}
+
+func (s *ServiceModeration) audit(ctx context.Context, actorID uuid.UUID, action string, targetType string, targetID uuid.UUID, before, after any, details map[string]any) error {
+	return s.repo.Audit.Record(ctx, domain.AuditEntry{
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Before:     before,
+		After:      after,
+		Details:    details,
+	})
+}
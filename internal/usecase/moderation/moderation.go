@@ -0,0 +1,172 @@
+package moderation
+
+import (
+	"OnlineLeadership/internal/domain"
+	"OnlineLeadership/internal/infrastructure/logger"
+	"OnlineLeadership/internal/infrastructure/repository"
+	"OnlineLeadership/internal/usecase/bans"
+	"OnlineLeadership/internal/usecase/games"
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 100
+)
+
+type ServiceModeration struct {
+	repo  *repository.Repository
+	games *games.Registry
+	bans  *bans.Checker
+	log   *logger.SlogLogger
+}
+
+func NewServiceModeration(repo *repository.Repository, registry *games.Registry, bans *bans.Checker, log *logger.SlogLogger) *ServiceModeration {
+	return &ServiceModeration{repo: repo, games: registry, bans: bans, log: log}
+}
+
+// FlagScore reports a score history entry for moderation on behalf of a player.
+func (s *ServiceModeration) FlagScore(ctx context.Context, reporterID uuid.UUID, scoreID uuid.UUID, reason string) (uuid.UUID, error) {
+	score, err := s.repo.ScoreHistory.Get(ctx, scoreID)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	// Only accepted submissions can be reviewed; the rest no longer count or are admin corrections.
+	if score.Status != domain.ScoreAccepted || score.Kind != domain.ScoreKindSubmission {
+		return uuid.UUID{}, domain.ErrScoreNotFound
+	}
+	id, err := s.repo.Flags.Create(ctx, domain.ScoreFlag{
+		ScoreID:    scoreID,
+		ReporterID: &reporterID,
+		Source:     domain.FlagSourcePlayer,
+		Reason:     strings.TrimSpace(reason),
+	})
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	s.log.Info(ctx, "score flagged", "flag_id", id, "score_id", scoreID, "reporter_id", reporterID)
+	return id, nil
+}
+
+// ListFlags returns the flags with the given status, oldest first.
+func (s *ServiceModeration) ListFlags(ctx context.Context, status string, offset int, limit int) ([]domain.ScoreFlag, error) {
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	limit = min(limit, MaxPageSize)
+	return s.repo.Flags.List(ctx, status, max(offset, 0), limit)
+}
+
+// ApproveFlag resolves the flag, and every other pending flag on the same score, leaving the score in place.
+func (s *ServiceModeration) ApproveFlag(ctx context.Context, id uuid.UUID, moderatorID uuid.UUID) error {
+	before, err := s.repo.Flags.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if _, _, err := s.repo.Flags.Decide(ctx, id, domain.FlagApproved, moderatorID); err != nil {
+		return err
+	}
+	s.log.Info(ctx, "flag approved", "flag_id", id, "moderator_id", moderatorID)
+	return s.auditDecision(ctx, moderatorID, domain.AuditFlagApprove, before)
+}
+
+// RejectFlag rejects the flagged score and removes its contribution from the
+// game, regional and global boards. Rejecting a flag that is already rejected
+// repeats the board update, so a rejection that failed half-way can be retried,
+// and still reports ErrFlagResolved.
+func (s *ServiceModeration) RejectFlag(ctx context.Context, id uuid.UUID, moderatorID uuid.UUID) error {
+	before, err := s.repo.Flags.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	score, rejected, err := s.repo.Flags.Decide(ctx, id, domain.FlagRejected, moderatorID)
+	if errors.Is(err, domain.ErrFlagResolved) && before.Status == domain.FlagRejected {
+		// A retry of a rejection whose board update failed: finish it.
+		return s.retryRemoval(ctx, before.ScoreID, err)
+	}
+	if err != nil {
+		return err
+	}
+	s.log.Info(ctx, "flag rejected", "flag_id", id, "score_id", score.Id, "moderator_id", moderatorID)
+	if rejected {
+		if err := s.removeContribution(ctx, score); err != nil {
+			s.log.Error(ctx, "remove rejected score from leaderboards error", err.Error(), "score_id", score.Id)
+			return err
+		}
+	}
+	return s.auditDecision(ctx, moderatorID, domain.AuditFlagReject, before)
+}
+
+// retryRemoval removes a score that was already rejected or voided from the
+// leaderboards again, in case the request that did so failed half-way, and
+// then reports the original outcome.
+func (s *ServiceModeration) retryRemoval(ctx context.Context, scoreID uuid.UUID, outcome error) error {
+	score, err := s.repo.ScoreHistory.Get(ctx, scoreID)
+	if err != nil {
+		return err
+	}
+	if score.Status == domain.ScoreAccepted {
+		return outcome
+	}
+	if err := s.removeContribution(ctx, score); err != nil {
+		s.log.Error(ctx, "remove score from leaderboards error", err.Error(), "score_id", scoreID)
+		return err
+	}
+	return outcome
+}
+
+// auditDecision records a moderator's decision on a flag with the flag's state around it.
+func (s *ServiceModeration) auditDecision(ctx context.Context, moderatorID uuid.UUID, action string, before domain.ScoreFlag) error {
+	after, err := s.repo.Flags.Get(ctx, before.Id)
+	if err != nil {
+		return err
+	}
+	return s.audit(ctx, moderatorID, action, domain.AuditTargetFlag, before.Id, before, after, map[string]any{
+		"score_id": before.ScoreID,
+		"user_id":  before.UserID,
+	})
+}
+
+// removeContribution takes a rejected score off the leaderboards by setting the
+// player's game score to what their remaining accepted history adds up to:
+// the sum for summed games, the best submission for max games. Because the
+// score is recomputed rather than subtracted, running it again for the same
+// score changes nothing, so a decision whose board update failed can be
+// completed by retrying it.
+// Users hidden by a visibility ban are skipped: their boards are rebuilt from
+// the score history when the ban is lifted.
+func (s *ServiceModeration) removeContribution(ctx context.Context, score domain.ScoreRecord) error {
+	if hidden, err := s.bans.Hidden(ctx, score.UserID); err != nil || hidden {
+		return err
+	}
+	game, err := s.games.Get(ctx, score.GameID)
+	if err != nil {
+		return err
+	}
+	user, err := s.repo.Auth.GetUserByID(ctx, score.UserID)
+	if err != nil {
+		return err
+	}
+	total, ok, err := s.repo.ScoreHistory.GameTotal(ctx, score.UserID, score.GameID)
+	if err != nil {
+		return err
+	}
+	value := total.Sum
+	if game.Config.WithDefaults().Aggregation == domain.AggregationMax {
+		value = total.Best
+	}
+
+	userID := score.UserID.String()
+	change, err := s.repo.LeaderBoard.ReplaceGameScore(ctx, score.GameID.String(), userID, user.Region, value, ok)
+	if err != nil {
+		return err
+	}
+	if change == 0 {
+		return nil
+	}
+	return s.repo.LeaderBoard.IncrementGlobalScore(ctx, userID, user.Region, int(change))
+}
@@ -0,0 +1,101 @@
+package profile
+
+import (
+	"OnlineLeadership/internal/domain"
+	"OnlineLeadership/internal/infrastructure/logger"
+	"OnlineLeadership/internal/infrastructure/repository"
+	"context"
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultScoresPageSize = 50
+	MaxScoresPageSize     = 100
+)
+
+type ServiceProfile struct {
+	repo *repository.Repository
+	log  *logger.SlogLogger
+}
+
+func NewServiceProfile(repo *repository.Repository, log *logger.SlogLogger) *ServiceProfile {
+	return &ServiceProfile{repo: repo, log: log}
+}
+
+func (s *ServiceProfile) GetProfile(ctx context.Context, userID uuid.UUID) (domain.User, error) {
+	user, err := s.repo.Auth.GetUserByID(ctx, userID)
+	if err != nil {
+		s.log.Error(ctx, "repo get profile error", err.Error())
+		return domain.User{}, err
+	}
+	return user, nil
+}
+
+// UpdateRegion changes the user's region and moves their existing scores
+// from the old regional boards to the new ones. The boards are moved before
+// the region is saved: if either step fails the user keeps the old region, and
+// repeating the request moves the boards again, which is harmless. A region
+// changed concurrently in between is moved away from as well.
+func (s *ServiceProfile) UpdateRegion(ctx context.Context, userID uuid.UUID, region string) error {
+	region, err := domain.NormalizeRegion(region)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.repo.Auth.GetUserByID(ctx, userID)
+	if err != nil {
+		s.log.Error(ctx, "repo get user error", err.Error())
+		return err
+	}
+	if user.Region == region {
+		return nil
+	}
+	games, err := s.repo.ScoreHistory.GetUserGames(ctx, userID)
+	if err != nil {
+		s.log.Error(ctx, "repo get user games error", err.Error())
+		return err
+	}
+	if err := s.repo.LeaderBoard.MoveRegion(ctx, userID, games, user.Region, region); err != nil {
+		s.log.Error(ctx, "repo move region error", err.Error())
+		return err
+	}
+
+	previous, err := s.repo.Auth.UpdateRegion(ctx, userID, region)
+	if err != nil {
+		s.log.Error(ctx, "repo update region error", err.Error())
+		return err
+	}
+	if previous != user.Region {
+		if err := s.repo.LeaderBoard.MoveRegion(ctx, userID, games, previous, region); err != nil {
+			s.log.Error(ctx, "repo move region error", err.Error())
+			return err
+		}
+	}
+	s.log.Info(ctx, "service update region passed", "from", previous, "to", region)
+	return nil
+}
+
+// ListScores returns a page of the user's score history, newest first, with
+// per-game aggregates of every entry matching the filter.
+func (s *ServiceProfile) ListScores(ctx context.Context, filter domain.ScoreHistoryFilter) (domain.ScoreHistoryPage, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultScoresPageSize
+	}
+	filter.Limit = min(filter.Limit, MaxScoresPageSize)
+
+	scores, err := s.repo.ScoreHistory.ListUserScores(ctx, filter)
+	if err != nil {
+		return domain.ScoreHistoryPage{}, err
+	}
+	summary, err := s.repo.ScoreHistory.UserScoreSummary(ctx, filter)
+	if err != nil {
+		return domain.ScoreHistoryPage{}, err
+	}
+	page := domain.ScoreHistoryPage{Scores: scores, Summary: summary}
+	if len(scores) > filter.Limit {
+		page.Scores = scores[:filter.Limit]
+		last := page.Scores[filter.Limit-1]
+		page.NextCursor = domain.TimeCursor(last.CreatedAt, last.Id).Encode()
+	}
+	return page, nil
+}
@@ -0,0 +1,142 @@
+package progress
+
+import (
+	"OnlineLeadership/internal/domain"
+	"OnlineLeadership/internal/infrastructure/logger"
+	"OnlineLeadership/internal/infrastructure/repository"
+	"OnlineLeadership/internal/usecase/bans"
+	"OnlineLeadership/internal/usecase/games"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// snapshotBatch is the number of board entries read from Redis and written to Postgres at a time.
+	snapshotBatch = 1000
+	// Retention is how long rank snapshots are kept.
+	Retention = 2 * 365 * 24 * time.Hour
+)
+
+type ServiceProgress struct {
+	repo  *repository.Repository
+	games *games.Registry
+	bans  *bans.Checker
+	log   *logger.SlogLogger
+}
+
+func NewServiceProgress(repo *repository.Repository, registry *games.Registry, bans *bans.Checker, log *logger.SlogLogger) *ServiceProgress {
+	return &ServiceProgress{repo: repo, games: registry, bans: bans, log: log}
+}
+
+// GetProgress returns the player's rank and score on a game board over time.
+// The series of users hidden by a visibility ban is empty for everyone but themselves.
+func (s *ServiceProgress) GetProgress(ctx context.Context, viewerID uuid.UUID, q domain.ProgressQuery) (domain.ProgressSeries, error) {
+	switch q.Bucket {
+	case "":
+		q.Bucket = domain.BucketDay
+	case domain.BucketHour, domain.BucketDay, domain.BucketWeek:
+	default:
+		return domain.ProgressSeries{}, domain.ErrInvalidBucket
+	}
+	if q.To.IsZero() {
+		q.To = time.Now()
+	}
+	if q.From.IsZero() {
+		q.From = q.To.Add(-domain.DefaultProgressSpan(q.Bucket))
+	}
+	if !q.From.Before(q.To) {
+		return domain.ProgressSeries{}, domain.ErrInvalidRange
+	}
+	if _, err := s.games.Get(ctx, q.GameID); err != nil {
+		return domain.ProgressSeries{}, err
+	}
+	if q.UserID != viewerID {
+		hidden, err := s.bans.Hidden(ctx, q.UserID)
+		if err != nil {
+			return domain.ProgressSeries{}, err
+		}
+		if hidden {
+			return domain.ProgressSeries{ProgressQuery: q, Points: []domain.ProgressPoint{}}, nil
+		}
+	}
+	points, err := s.repo.Snapshots.Series(ctx, q)
+	if err != nil {
+		return domain.ProgressSeries{}, err
+	}
+	return domain.ProgressSeries{ProgressQuery: q, Points: points}, nil
+}
+
+// Snapshot records the rank and score of every player on every active game
+// board. The snapshot time is truncated to interval so that replicas taking a
+// snapshot in the same interval write the same rows once.
+func (s *ServiceProgress) Snapshot(ctx context.Context, interval time.Duration) (int64, error) {
+	takenAt := time.Now().UTC().Truncate(interval)
+	all, err := s.repo.Admin.GetGames(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var saved int64
+	for _, game := range all {
+		// Archived boards no longer change.
+		if game.Archived() {
+			continue
+		}
+		n, err := s.snapshotGame(ctx, game.Id, takenAt)
+		saved += n
+		if err != nil {
+			return saved, err
+		}
+	}
+	if _, err := s.repo.Snapshots.Prune(ctx, takenAt.Add(-Retention)); err != nil {
+		return saved, err
+	}
+	return saved, nil
+}
+
+// snapshotGame saves the game board page by page. Pages follow a cursor, so
+// players whose score holds still while the board is read are neither skipped
+// nor read twice, as they could be with offsets when others move ahead of them.
+func (s *ServiceProgress) snapshotGame(ctx context.Context, gameID uuid.UUID, takenAt time.Time) (int64, error) {
+	var saved int64
+	req := domain.PageRequest{Limit: snapshotBatch}
+	for {
+		page, err := s.repo.LeaderBoard.GetLeaderboard(ctx, gameID, "", req)
+		if err != nil {
+			return saved, err
+		}
+		n, err := s.repo.Snapshots.Save(ctx, gameID, takenAt, page.Users)
+		saved += n
+		if err != nil {
+			return saved, err
+		}
+		if page.NextCursor == "" {
+			return saved, nil
+		}
+		cursor, err := domain.DecodeCursor(page.NextCursor)
+		if err != nil {
+			return saved, err
+		}
+		req.Cursor = &cursor
+	}
+}
+
+// RunSnapshotter takes a snapshot every interval until ctx is cancelled.
+func (s *ServiceProgress) RunSnapshotter(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			saved, err := s.Snapshot(ctx, interval)
+			if err != nil {
+				s.log.Error(ctx, "rank snapshot error", err.Error(), "rows", saved)
+				continue
+			}
+			s.log.Info(ctx, "rank snapshot taken", "rows", saved)
+		}
+	}
+}
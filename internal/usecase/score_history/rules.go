@@ -0,0 +1,160 @@
+package score_history
+
+import (
+	"OnlineLeadership/internal/domain"
+	"OnlineLeadership/internal/infrastructure/repository"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// outlierSampleSize is how many of a game's most recent submissions the outlier rule compares against.
+	outlierSampleSize = 10000
+	// outlierMinSamples keeps the outlier rule off until a game has enough submissions to judge by.
+	outlierMinSamples = 30
+	// statsTTL bounds how long a game's submission statistics are reused.
+	statsTTL = time.Minute
+)
+
+// rule checks one submission and returns a violation, or nil when the submission passes.
+type rule func(ctx context.Context, config domain.GameConfig, userID uuid.UUID, gameID uuid.UUID, score int) (*domain.RuleViolation, error)
+
+// RulesEngine evaluates a game's anti-cheat rules against a submission.
+// Rules run from the cheapest to the most expensive and stop at the first violation.
+type RulesEngine struct {
+	repo  repository.ScoreHistory
+	rules []rule
+
+	mu    sync.Mutex
+	stats map[uuid.UUID]cachedStats
+}
+
+type cachedStats struct {
+	stats    domain.ScoreStats
+	loadedAt time.Time
+}
+
+func NewRulesEngine(repo repository.ScoreHistory) *RulesEngine {
+	e := &RulesEngine{repo: repo, stats: make(map[uuid.UUID]cachedStats)}
+	e.rules = []rule{e.scoreBounds, e.submissionRate, e.hourlyGain, e.outlier}
+	return e
+}
+
+// Evaluate returns the first rule the submission breaks, or nil when it passes them all.
+func (e *RulesEngine) Evaluate(ctx context.Context, config domain.GameConfig, userID uuid.UUID, gameID uuid.UUID, score int) (*domain.RuleViolation, error) {
+	for _, check := range e.rules {
+		violation, err := check(ctx, config, userID, gameID, score)
+		if err != nil || violation != nil {
+			return violation, err
+		}
+	}
+	return nil, nil
+}
+
+func (e *RulesEngine) scoreBounds(_ context.Context, config domain.GameConfig, _ uuid.UUID, _ uuid.UUID, score int) (*domain.RuleViolation, error) {
+	if config.CheckScore(int64(score)) == nil {
+		return nil, nil
+	}
+	return &domain.RuleViolation{
+		Rule:   domain.RuleScoreBounds,
+		Reason: fmt.Sprintf("score %d is outside [%s, %s]", score, bound(config.MinScore, "-inf"), bound(config.MaxScore, "+inf")),
+	}, nil
+}
+
+func (e *RulesEngine) submissionRate(ctx context.Context, config domain.GameConfig, userID uuid.UUID, gameID uuid.UUID, _ int) (*domain.RuleViolation, error) {
+	if config.MaxSubmissions == 0 {
+		return nil, nil
+	}
+	window := time.Duration(config.SubmissionWindow) * time.Second
+	count, err := e.repo.CountSince(ctx, userID, gameID, time.Now().Add(-window))
+	if err != nil {
+		return nil, err
+	}
+	if count < config.MaxSubmissions {
+		return nil, nil
+	}
+	return &domain.RuleViolation{
+		Rule:   domain.RuleSubmissionRate,
+		Reason: fmt.Sprintf("more than %d submissions in %s", config.MaxSubmissions, window),
+	}, nil
+}
+
+func (e *RulesEngine) hourlyGain(ctx context.Context, config domain.GameConfig, userID uuid.UUID, gameID uuid.UUID, score int) (*domain.RuleViolation, error) {
+	if config.MaxGainPerHour == 0 {
+		return nil, nil
+	}
+	sum, err := e.repo.SumSince(ctx, userID, gameID, time.Now().Add(-time.Hour))
+	if err != nil {
+		return nil, err
+	}
+	if sum+int64(score) <= config.MaxGainPerHour {
+		return nil, nil
+	}
+	return &domain.RuleViolation{
+		Rule:   domain.RuleHourlyGain,
+		Reason: fmt.Sprintf("gain of %d in the last hour exceeds %d", sum+int64(score), config.MaxGainPerHour),
+	}, nil
+}
+
+func (e *RulesEngine) outlier(ctx context.Context, config domain.GameConfig, _ uuid.UUID, gameID uuid.UUID, score int) (*domain.RuleViolation, error) {
+	return e.aboveZScore(ctx, domain.RuleOutlier, config.OutlierZScore, gameID, score)
+}
+
+// Suspicious reports an accepted submission that should still be flagged for
+// moderation because it lies above the game's FlagZScore.
+func (e *RulesEngine) Suspicious(ctx context.Context, config domain.GameConfig, gameID uuid.UUID, score int) (*domain.RuleViolation, error) {
+	return e.aboveZScore(ctx, domain.RuleOutlier, config.FlagZScore, gameID, score)
+}
+
+// aboveZScore reports a violation of rule when the score is more than limit
+// standard deviations above the mean of the game's recent submissions.
+func (e *RulesEngine) aboveZScore(ctx context.Context, rule string, limit float64, gameID uuid.UUID, score int) (*domain.RuleViolation, error) {
+	if limit == 0 {
+		return nil, nil
+	}
+	stats, err := e.gameStats(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if stats.Count < outlierMinSamples || stats.StdDev == 0 {
+		return nil, nil
+	}
+	z := (float64(score) - stats.Mean) / stats.StdDev
+	if z <= limit {
+		return nil, nil
+	}
+	return &domain.RuleViolation{
+		Rule:   rule,
+		Reason: fmt.Sprintf("score is %.1f standard deviations above the mean of %.1f", z, stats.Mean),
+	}, nil
+}
+
+// gameStats returns the game's submission statistics, reloading them after statsTTL.
+func (e *RulesEngine) gameStats(ctx context.Context, gameID uuid.UUID) (domain.ScoreStats, error) {
+	e.mu.Lock()
+	cached, ok := e.stats[gameID]
+	e.mu.Unlock()
+	if ok && time.Since(cached.loadedAt) < statsTTL {
+		return cached.stats, nil
+	}
+
+	stats, err := e.repo.GameStats(ctx, gameID, outlierSampleSize)
+	if err != nil {
+		return domain.ScoreStats{}, err
+	}
+	e.mu.Lock()
+	e.stats[gameID] = cachedStats{stats: stats, loadedAt: time.Now()}
+	e.mu.Unlock()
+	return stats, nil
+}
+
+func bound(v *int64, unset string) string {
+	if v == nil {
+		return unset
+	}
+	return fmt.Sprint(*v)
+}
@@ -0,0 +1,99 @@
+package score_history
+
+import (
+	"OnlineLeadership/internal/domain"
+	"OnlineLeadership/internal/infrastructure/repository"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fakeHistory answers the rule queries with fixed values.
+type fakeHistory struct {
+	repository.ScoreHistory
+	count int
+	sum   int64
+	stats domain.ScoreStats
+	// statsLoads counts the GameStats calls.
+	statsLoads int
+}
+
+func (f *fakeHistory) CountSince(context.Context, uuid.UUID, uuid.UUID, time.Time) (int, error) {
+	return f.count, nil
+}
+
+func (f *fakeHistory) SumSince(context.Context, uuid.UUID, uuid.UUID, time.Time) (int64, error) {
+	return f.sum, nil
+}
+
+func (f *fakeHistory) GameStats(context.Context, uuid.UUID, int) (domain.ScoreStats, error) {
+	f.statsLoads++
+	return f.stats, nil
+}
+
+func TestRulesEngineEvaluate(t *testing.T) {
+	bound := func(v int64) *int64 { return &v }
+	recent := domain.ScoreStats{Count: outlierMinSamples, Mean: 100, StdDev: 10}
+	tests := []struct {
+		name    string
+		config  domain.GameConfig
+		history fakeHistory
+		score   int
+		want    string
+	}{
+		{"no rules", domain.GameConfig{}, fakeHistory{count: 1000, sum: 1 << 40, stats: recent}, 1 << 30, ""},
+		{"within bounds", domain.GameConfig{MinScore: bound(0), MaxScore: bound(100)}, fakeHistory{}, 100, ""},
+		{"below min", domain.GameConfig{MinScore: bound(0)}, fakeHistory{}, -1, domain.RuleScoreBounds},
+		{"above max", domain.GameConfig{MaxScore: bound(100)}, fakeHistory{}, 101, domain.RuleScoreBounds},
+		{"under rate", domain.GameConfig{MaxSubmissions: 5, SubmissionWindow: 60}, fakeHistory{count: 4}, 1, ""},
+		{"at rate", domain.GameConfig{MaxSubmissions: 5, SubmissionWindow: 60}, fakeHistory{count: 5}, 1, domain.RuleSubmissionRate},
+		{"gain at cap", domain.GameConfig{MaxGainPerHour: 1000}, fakeHistory{sum: 900}, 100, ""},
+		{"gain over cap", domain.GameConfig{MaxGainPerHour: 1000}, fakeHistory{sum: 900}, 101, domain.RuleHourlyGain},
+		{"at z-score", domain.GameConfig{OutlierZScore: 3}, fakeHistory{stats: recent}, 130, ""},
+		{"outlier", domain.GameConfig{OutlierZScore: 3}, fakeHistory{stats: recent}, 131, domain.RuleOutlier},
+		{"too few samples", domain.GameConfig{OutlierZScore: 3}, fakeHistory{stats: domain.ScoreStats{Count: outlierMinSamples - 1, Mean: 100, StdDev: 10}}, 1000, ""},
+		{"no spread", domain.GameConfig{OutlierZScore: 3}, fakeHistory{stats: domain.ScoreStats{Count: outlierMinSamples, Mean: 100}}, 1000, ""},
+		{"cheapest rule first", domain.GameConfig{MaxScore: bound(100), MaxSubmissions: 1, SubmissionWindow: 60}, fakeHistory{count: 1}, 101, domain.RuleScoreBounds},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violation, err := NewRulesEngine(&tt.history).Evaluate(context.Background(), tt.config, uuid.New(), uuid.New(), tt.score)
+			if err != nil {
+				t.Fatalf("Evaluate: %v", err)
+			}
+			got := ""
+			if violation != nil {
+				got = violation.Rule
+			}
+			if got != tt.want {
+				t.Fatalf("Evaluate() rule = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRulesEngineSuspicious(t *testing.T) {
+	history := &fakeHistory{stats: domain.ScoreStats{Count: outlierMinSamples, Mean: 100, StdDev: 10}}
+	engine := NewRulesEngine(history)
+	gameID := uuid.New()
+	config := domain.GameConfig{FlagZScore: 2}
+
+	for _, tt := range []struct {
+		score int
+		flag  bool
+	}{{120, false}, {121, true}} {
+		violation, err := engine.Suspicious(context.Background(), config, gameID, tt.score)
+		if err != nil {
+			t.Fatalf("Suspicious: %v", err)
+		}
+		if (violation != nil) != tt.flag {
+			t.Errorf("Suspicious(%d) = %v, want flagged %v", tt.score, violation, tt.flag)
+		}
+	}
+	// The statistics are loaded once per statsTTL.
+	if history.statsLoads != 1 {
+		t.Errorf("GameStats loaded %d times, want 1", history.statsLoads)
+	}
+}
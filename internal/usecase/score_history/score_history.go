@@ -1,44 +1,293 @@
 package score_history
 
 import (
+	"OnlineLeadership/internal/domain"
 	"OnlineLeadership/internal/infrastructure/logger"
+	"OnlineLeadership/internal/infrastructure/metrics"
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"OnlineLeadership/internal/infrastructure/repository"
+	"OnlineLeadership/internal/usecase/bans"
+	"OnlineLeadership/internal/usecase/games"
 
 	"github.com/google/uuid"
 )
 
+const (
+	DefaultQuarantinePageSize = 50
+	MaxQuarantinePageSize     = 100
+)
+
 type ScoreService struct {
-	repo *repository.Repository
-	log  *logger.SlogLogger
+	repo  *repository.Repository
+	games *games.Registry
+	bans  *bans.Checker
+	rules *RulesEngine
+	log   *logger.SlogLogger
 }
 
-func NewScoreService(repo *repository.Repository, slogLogger *logger.SlogLogger) *ScoreService {
-	return &ScoreService{repo: repo, log: slogLogger}
+func NewScoreService(repo *repository.Repository, registry *games.Registry, bans *bans.Checker, slogLogger *logger.SlogLogger) *ScoreService {
+	return &ScoreService{repo: repo, games: registry, bans: bans, rules: NewRulesEngine(repo.ScoreHistory), log: slogLogger}
 }
 
-func (s *ScoreService) SubmitScore(ctx context.Context, userID uuid.UUID, gameID uuid.UUID, score int) error {
+func (s *ScoreService) SubmitScore(ctx context.Context, submission domain.ScoreSubmission) (err error) {
+	userID, gameID, score := submission.UserID, submission.GameID, submission.Score
 	s.log.Info(ctx, "submit score",
 		"user_id", userID,
 		"game_id", gameID,
 		"score", score,
 	)
 
+	if err := s.bans.Check(ctx, userID, domain.BanScopeSubmit); err != nil {
+		return err
+	}
+
+	// Unknown and archived games are rejected before anything is written.
+	game, err := s.games.Active(ctx, gameID)
+	if err != nil {
+		return err
+	}
+	// Only submissions for existing games are counted, keeping the game_id label bounded.
+	defer func() { metrics.ObserveSubmission(gameID.String(), err) }()
+	config := game.Config.WithDefaults()
+
+	if game.Signed() {
+		if err := s.verifySignature(ctx, game, submission); err != nil {
+			s.log.Warn(ctx, "score signature rejected", "user_id", userID, "game_id", gameID, "error", err.Error())
+			return err
+		}
+	}
+
+	// Submissions breaking an anti-cheat rule are held for review and never reach Redis.
+	violation, err := s.rules.Evaluate(ctx, config, userID, gameID, score)
+	if err != nil {
+		return err
+	}
+	if violation != nil {
+		s.log.Warn(ctx, "score quarantined",
+			"user_id", userID,
+			"game_id", gameID,
+			"score", score,
+			"rule", violation.Rule,
+			"reason", violation.Reason,
+		)
+		_, err := s.repo.Quarantine.Save(ctx, domain.QuarantinedScore{
+			UserID: userID,
+			GameID: gameID,
+			Score:  score,
+			Rule:   violation.Rule,
+			Reason: violation.Reason,
+		})
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: %s", domain.ErrScoreQuarantined, violation.Reason)
+	}
+
+	scoreID, err := s.apply(ctx, userID, gameID, config, score)
+	if err != nil {
+		return err
+	}
+	s.flagIfSuspicious(ctx, config, scoreID, gameID, score)
+	return nil
+}
+
+// flagIfSuspicious raises an automated moderation flag on an accepted score.
+// The score is already on the leaderboards, so failures are only logged.
+func (s *ScoreService) flagIfSuspicious(ctx context.Context, config domain.GameConfig, scoreID uuid.UUID, gameID uuid.UUID, score int) {
+	violation, err := s.rules.Suspicious(ctx, config, gameID, score)
+	if err != nil {
+		s.log.Error(ctx, "evaluate flag rules error", err.Error(), "score_id", scoreID)
+		return
+	}
+	if violation == nil {
+		return
+	}
+	_, err = s.repo.Flags.Create(ctx, domain.ScoreFlag{
+		ScoreID: scoreID,
+		Source:  domain.FlagSourceRule,
+		Reason:  violation.Reason,
+	})
+	if err != nil {
+		s.log.Error(ctx, "create automated flag error", err.Error(), "score_id", scoreID)
+		return
+	}
+	s.log.Info(ctx, "score flagged for moderation", "score_id", scoreID, "reason", violation.Reason)
+}
+
+// verifySignature checks a submission to a signed game and claims its nonce.
+// The nonce is kept for twice the allowed clock skew, which covers every
+// timestamp that can still pass the staleness check.
+func (s *ScoreService) verifySignature(ctx context.Context, game domain.Game, submission domain.ScoreSubmission) error {
+	if err := submission.Verify(game.SigningSecret, time.Now()); err != nil {
+		return err
+	}
+	fresh, err := s.repo.Nonces.Claim(ctx, game.Id, submission.Nonce, 2*domain.SubmissionMaxSkew)
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		return domain.ErrNonceReused
+	}
+	return nil
+}
+
+// apply records an accepted score, adds it to the game, regional and global
+// boards and returns the id of its history entry.
+func (s *ScoreService) apply(ctx context.Context, userID uuid.UUID, gameID uuid.UUID, config domain.GameConfig, score int) (uuid.UUID, error) {
 	// 1️⃣ сохраняем историю (Postgres)
-	if err := s.repo.ScoreHistory.Save(ctx, userID, gameID, score); err != nil {
+	scoreID, err := s.repo.ScoreHistory.Save(ctx, userID, gameID, score)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	if err := s.addToBoards(ctx, userID, gameID, config, score); err != nil {
+		return uuid.UUID{}, err
+	}
+	return scoreID, nil
+}
+
+// addToBoards adds a recorded score to the game, regional and global boards.
+// Scores of users hidden by a visibility ban are only recorded; the boards are
+// rebuilt when the ban is lifted.
+func (s *ScoreService) addToBoards(ctx context.Context, userID uuid.UUID, gameID uuid.UUID, config domain.GameConfig, score int) error {
+	if hidden, err := s.bans.Hidden(ctx, userID); err != nil || hidden {
+		return err
+	}
+	user, err := s.repo.Auth.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// 2️⃣ обновляем leaderboard игры и региона (Redis)
+	gain := score
+	if config.Aggregation == domain.AggregationMax {
+		delta, err := s.repo.LeaderBoard.SetBestGameScore(ctx, gameID.String(), userID.String(), user.Region, score)
+		if err != nil {
+			return err
+		}
+		gain = int(delta)
+	} else if err := s.repo.LeaderBoard.IncrementGameScore(ctx, gameID.String(), userID.String(), user.Region, score); err != nil {
 		return err
 	}
 
-	// 2️⃣ обновляем leaderboard игры (Redis)
-	if err := s.repo.LeaderBoard.IncrementGameScore(ctx, gameID.String(), userID.String(), score); err != nil {
+	// 3️⃣ обновляем глобальный leaderboard: он суммирует очки игроков по всем играм
+	return s.repo.LeaderBoard.IncrementGlobalScore(ctx, userID.String(), user.Region, gain)
+}
+
+// syncBoards sets the user's game and global scores to what their accepted
+// score history adds up to, as lifting a visibility ban does. Running it
+// again changes nothing, so it completes a board update that failed half-way.
+func (s *ScoreService) syncBoards(ctx context.Context, userID uuid.UUID) error {
+	if hidden, err := s.bans.Hidden(ctx, userID); err != nil || hidden {
+		return err
+	}
+	user, err := s.repo.Auth.GetUserByID(ctx, userID)
+	if err != nil {
 		return err
 	}
+	totals, err := s.repo.ScoreHistory.UserGameTotals(ctx, userID)
+	if err != nil {
+		return err
+	}
+	var global int64
+	for _, total := range totals {
+		game, err := s.games.Get(ctx, total.GameID)
+		if err != nil {
+			return err
+		}
+		score := total.Sum
+		if game.Config.WithDefaults().Aggregation == domain.AggregationMax {
+			score = total.Best
+		}
+		if _, err := s.repo.LeaderBoard.ReplaceGameScore(ctx, total.GameID.String(), userID.String(), user.Region, score, true); err != nil {
+			return err
+		}
+		global += score
+	}
+	return s.repo.LeaderBoard.SetGlobalScore(ctx, userID.String(), user.Region, global)
+}
 
-	// 3️⃣ обновляем глобальный leaderboard
-	if err := s.repo.LeaderBoard.IncrementGlobalScore(ctx, userID.String(), score); err != nil {
+// ListQuarantine returns the quarantined submissions with the given status, oldest first.
+func (s *ScoreService) ListQuarantine(ctx context.Context, status string, offset int, limit int) ([]domain.QuarantinedScore, error) {
+	if limit <= 0 {
+		limit = DefaultQuarantinePageSize
+	}
+	limit = min(limit, MaxQuarantinePageSize)
+	return s.repo.Quarantine.List(ctx, status, max(offset, 0), limit)
+}
+
+// ReleaseQuarantined accepts a quarantined submission as if it had passed the rules.
+// Releasing works for archived games too, since the score was submitted before archiving.
+// The release and the history entry are written in one transaction, so the
+// score is recorded once. Releasing a submission that is already released
+// syncs the player's boards with their history, so a release whose board
+// update failed can be retried, and still reports ErrQuarantineResolved.
+func (s *ScoreService) ReleaseQuarantined(ctx context.Context, actorID uuid.UUID, id uuid.UUID) error {
+	score, err := s.repo.Quarantine.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	game, err := s.games.Get(ctx, score.GameID)
+	if err != nil {
 		return err
 	}
 
+	// Claim the submission first so that two moderators cannot apply it twice.
+	_, err = s.repo.Quarantine.Release(ctx, id)
+	if errors.Is(err, domain.ErrQuarantineResolved) && score.Status == domain.QuarantineReleased {
+		if err := s.syncBoards(ctx, score.UserID); err != nil {
+			s.log.Error(ctx, "sync released score error", err.Error(), "id", id)
+			return err
+		}
+		return domain.ErrQuarantineResolved
+	}
+	if err != nil {
+		return err
+	}
+	s.log.Info(ctx, "quarantined score released", "id", id, "user_id", score.UserID, "game_id", score.GameID, "actor_id", actorID)
+	if err := s.auditResolution(ctx, actorID, domain.AuditQuarantineRelease, score); err != nil {
+		return err
+	}
+	if err := s.addToBoards(ctx, score.UserID, score.GameID, game.Config.WithDefaults(), score.Score); err != nil {
+		s.log.Error(ctx, "apply released score error", err.Error(), "id", id)
+		return err
+	}
 	return nil
 }
+
+// DiscardQuarantined rejects a quarantined submission for good.
+func (s *ScoreService) DiscardQuarantined(ctx context.Context, actorID uuid.UUID, id uuid.UUID) error {
+	score, err := s.repo.Quarantine.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Quarantine.Resolve(ctx, id, domain.QuarantinePending, domain.QuarantineDiscarded); err != nil {
+		return err
+	}
+	s.log.Info(ctx, "quarantined score discarded", "id", id, "actor_id", actorID)
+	return s.auditResolution(ctx, actorID, domain.AuditQuarantineDiscard, score)
+}
+
+// auditResolution records the resolution of a quarantined submission with its state around it.
+func (s *ScoreService) auditResolution(ctx context.Context, actorID uuid.UUID, action string, before domain.QuarantinedScore) error {
+	after, err := s.repo.Quarantine.Get(ctx, before.Id)
+	if err != nil {
+		return err
+	}
+	return s.repo.Audit.Record(ctx, domain.AuditEntry{
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: domain.AuditTargetQuarantine,
+		TargetID:   before.Id,
+		Before:     before,
+		After:      after,
+		Details: map[string]any{
+			"user_id": before.UserID,
+			"game_id": before.GameID,
+			"score":   before.Score,
+		},
+	})
+}
@@ -0,0 +1,157 @@
+package score_history
+
+import (
+	"OnlineLeadership/internal/domain"
+	"OnlineLeadership/internal/infrastructure/logger"
+	leader "OnlineLeadership/internal/infrastructure/postgres/leaderboard"
+	"OnlineLeadership/internal/infrastructure/repository"
+	"OnlineLeadership/internal/usecase/bans"
+	"OnlineLeadership/internal/usecase/games"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// releaseStore keeps the quarantine and the score history in memory, releasing
+// a submission the way the Postgres repository does: status and history entry together.
+type releaseStore struct {
+	repository.Quarantine
+	quarantined map[uuid.UUID]domain.QuarantinedScore
+	history     []domain.QuarantinedScore
+}
+
+// releasedHistory serves the totals of the scores released into store.
+type releasedHistory struct {
+	repository.ScoreHistory
+	store *releaseStore
+}
+
+func (f *releaseStore) Get(_ context.Context, id uuid.UUID) (domain.QuarantinedScore, error) {
+	score, ok := f.quarantined[id]
+	if !ok {
+		return domain.QuarantinedScore{}, domain.ErrQuarantineNotFound
+	}
+	return score, nil
+}
+
+func (f *releaseStore) Release(_ context.Context, id uuid.UUID) (uuid.UUID, error) {
+	score := f.quarantined[id]
+	if score.Status != domain.QuarantinePending {
+		return uuid.UUID{}, domain.ErrQuarantineResolved
+	}
+	scoreID := uuid.New()
+	score.Status, score.ScoreID = domain.QuarantineReleased, &scoreID
+	f.quarantined[id] = score
+	f.history = append(f.history, score)
+	return scoreID, nil
+}
+
+func (f releasedHistory) UserGameTotals(_ context.Context, userID uuid.UUID) ([]domain.GameTotal, error) {
+	totals := map[uuid.UUID]*domain.GameTotal{}
+	for _, score := range f.store.history {
+		if score.UserID != userID {
+			continue
+		}
+		total, ok := totals[score.GameID]
+		if !ok {
+			total = &domain.GameTotal{GameID: score.GameID}
+			totals[score.GameID] = total
+		}
+		total.Sum += int64(score.Score)
+		total.Best = max(total.Best, int64(score.Score))
+	}
+	var list []domain.GameTotal
+	for _, total := range totals {
+		list = append(list, *total)
+	}
+	return list, nil
+}
+
+// flakyBoard fails the next global board update when failGlobal is set.
+type flakyBoard struct {
+	repository.LeaderBoard
+	failGlobal bool
+}
+
+func (b *flakyBoard) IncrementGlobalScore(ctx context.Context, userID string, region string, score int) error {
+	if b.failGlobal {
+		b.failGlobal = false
+		return errors.New("connection reset")
+	}
+	return b.LeaderBoard.IncrementGlobalScore(ctx, userID, region, score)
+}
+
+type fakeUsers struct{ repository.Auth }
+
+func (fakeUsers) GetUserByID(_ context.Context, userID uuid.UUID) (domain.User, error) {
+	return domain.User{Id: userID}, nil
+}
+
+type fakeGames struct {
+	repository.Admin
+	games []domain.Game
+}
+
+func (f fakeGames) GetGames(context.Context) ([]domain.Game, error) {
+	return f.games, nil
+}
+
+type noBans struct{ repository.Bans }
+
+func (noBans) ListActive(context.Context) ([]domain.Ban, error) {
+	return nil, nil
+}
+
+type discardAudit struct{ repository.Audit }
+
+func (discardAudit) Record(context.Context, domain.AuditEntry) error {
+	return nil
+}
+
+func TestReleaseQuarantinedRetry(t *testing.T) {
+	ctx := context.Background()
+	log := logger.New("error", logger.FormatText)
+	game := domain.Game{Id: uuid.New(), Config: domain.GameConfig{Aggregation: domain.AggregationSum}}
+	userID := uuid.New()
+	pending := domain.QuarantinedScore{Id: uuid.New(), UserID: userID, GameID: game.Id, Score: 40, Status: domain.QuarantinePending}
+
+	store := &releaseStore{quarantined: map[uuid.UUID]domain.QuarantinedScore{pending.Id: pending}}
+	board := &flakyBoard{LeaderBoard: leader.NewMemoryLeaderboard(), failGlobal: true}
+	repo := &repository.Repository{
+		Auth:         fakeUsers{},
+		ScoreHistory: releasedHistory{store: store},
+		LeaderBoard:  board,
+		Quarantine:   store,
+		Audit:        discardAudit{},
+	}
+	service := NewScoreService(repo, games.NewRegistry(fakeGames{games: []domain.Game{game}}, log, time.Hour), bans.NewChecker(noBans{}, log, time.Hour), log)
+
+	// The game board is updated, then the global board fails.
+	if err := service.ReleaseQuarantined(ctx, uuid.New(), pending.Id); err == nil {
+		t.Fatal("ReleaseQuarantined succeeded despite the failing global board")
+	}
+	// The retry completes the boards without recording the score again.
+	if err := service.ReleaseQuarantined(ctx, uuid.New(), pending.Id); !errors.Is(err, domain.ErrQuarantineResolved) {
+		t.Fatalf("retry error = %v, want %v", err, domain.ErrQuarantineResolved)
+	}
+	if len(store.history) != 1 {
+		t.Fatalf("history has %d entries, want 1", len(store.history))
+	}
+
+	gamePage, err := board.GetLeaderboard(ctx, game.Id, "", domain.PageRequest{Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	globalPage, err := board.GetGlobal(ctx, "", domain.PageRequest{Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, page := range map[string]domain.LeaderboardPage{"game": gamePage, "global": globalPage} {
+		if len(page.Users) != 1 || page.Users[0].UserID != userID || page.Users[0].Score != 40 {
+			t.Errorf("%s board = %+v, want the user at 40", name, page.Users)
+		}
+	}
+}
@@ -3,13 +3,21 @@ package usecase
 import (
 	"OnlineLeadership/internal/domain"
 	"OnlineLeadership/internal/infrastructure/logger"
+	"OnlineLeadership/internal/infrastructure/postgres/migration"
 	"OnlineLeadership/internal/infrastructure/repository"
 	"OnlineLeadership/internal/usecase/admin"
 	"OnlineLeadership/internal/usecase/auth"
+	"OnlineLeadership/internal/usecase/bans"
+	"OnlineLeadership/internal/usecase/games"
+	"OnlineLeadership/internal/usecase/health"
 	"OnlineLeadership/internal/usecase/leaderboard"
+	"OnlineLeadership/internal/usecase/moderation"
+	"OnlineLeadership/internal/usecase/profile"
+	"OnlineLeadership/internal/usecase/progress"
 	"OnlineLeadership/internal/usecase/score_history"
 	"context"
 	"github.com/google/uuid"
+	"time"
 )
 
 type Auth interface {
@@ -20,29 +28,97 @@ type Auth interface {
 	GenerateAccessToken(userId string) (string, error)
 }
 type ScoreHistory interface {
-	SubmitScore(ctx context.Context, userID uuid.UUID, gameID uuid.UUID, score int) error
+	SubmitScore(ctx context.Context, submission domain.ScoreSubmission) error
+	ListQuarantine(ctx context.Context, status string, offset int, limit int) ([]domain.QuarantinedScore, error)
+	ReleaseQuarantined(ctx context.Context, actorID uuid.UUID, id uuid.UUID) error
+	DiscardQuarantined(ctx context.Context, actorID uuid.UUID, id uuid.UUID) error
 }
 type Admin interface {
-	Create(ctx context.Context, name string) (uuid.UUID, error)
+	Create(ctx context.Context, actorID uuid.UUID, game domain.Game) (uuid.UUID, error)
 	GetGames(ctx context.Context) ([]domain.Game, error)
+	ListGames(ctx context.Context) ([]domain.Game, error)
+	GetGame(ctx context.Context, id uuid.UUID) (domain.Game, error)
+	UpdateGame(ctx context.Context, actorID uuid.UUID, game domain.Game) error
+	ArchiveGame(ctx context.Context, actorID uuid.UUID, id uuid.UUID) error
+	RestoreGame(ctx context.Context, actorID uuid.UUID, id uuid.UUID) error
+	RotateSigningSecret(ctx context.Context, actorID uuid.UUID, id uuid.UUID) (string, error)
+	DisableSigning(ctx context.Context, actorID uuid.UUID, id uuid.UUID) error
+	DeleteGame(ctx context.Context, actorID uuid.UUID, id uuid.UUID) error
+	ListAudit(ctx context.Context, filter domain.AuditFilter) (domain.AuditPage, error)
+	RebuildLeaderboard(ctx context.Context, actorID uuid.UUID, id uuid.UUID) (int, error)
+	FindUser(ctx context.Context, username string) (domain.User, error)
+	SetRole(ctx context.Context, actorID uuid.UUID, userID uuid.UUID, role string) error
 }
 type Leaderboard interface {
-	GetGlobalLeaderboard(ctx context.Context, offset, limit int) ([]domain.LeaderboardUser, error)
-	GetLeaderboard(ctx context.Context, gameID uuid.UUID) ([]domain.LeaderboardUser, error)
-	GetMyRank(ctx context.Context, userID uuid.UUID) (int64, error)
+	GetGlobalLeaderboard(ctx context.Context, region string, page domain.PageRequest) (domain.LeaderboardPage, error)
+	GetLeaderboard(ctx context.Context, gameID uuid.UUID, region string, page domain.PageRequest) (domain.LeaderboardPage, error)
+	GetMyRank(ctx context.Context, userID uuid.UUID) (domain.PlayerRank, error)
+	GetLeaderboardVersion(ctx context.Context, gameID uuid.UUID) (domain.BoardVersion, error)
+	GetGameRank(ctx context.Context, userID uuid.UUID, gameID uuid.UUID) (domain.PlayerRank, error)
+	GetDistribution(ctx context.Context, gameID uuid.UUID, region string, buckets int) (domain.ScoreDistribution, error)
+	BoardSizes(ctx context.Context) (map[string]int64, error)
+}
+type Moderation interface {
+	FlagScore(ctx context.Context, reporterID uuid.UUID, scoreID uuid.UUID, reason string) (uuid.UUID, error)
+	ListFlags(ctx context.Context, status string, offset int, limit int) ([]domain.ScoreFlag, error)
+	ApproveFlag(ctx context.Context, id uuid.UUID, moderatorID uuid.UUID) error
+	RejectFlag(ctx context.Context, id uuid.UUID, moderatorID uuid.UUID) error
+	VoidScore(ctx context.Context, actorID uuid.UUID, scoreID uuid.UUID, reason string) error
+	AdjustScore(ctx context.Context, actorID uuid.UUID, gameID uuid.UUID, userID uuid.UUID, delta int, reason string) error
+	RemoveUser(ctx context.Context, actorID uuid.UUID, userID uuid.UUID, gameID *uuid.UUID, reason string) error
+}
+type Bans interface {
+	CheckBan(ctx context.Context, userID uuid.UUID, scope string) error
+	Ban(ctx context.Context, actorID uuid.UUID, userID uuid.UUID, scopes []string, reason string, duration time.Duration) (uuid.UUID, error)
+	ListBans(ctx context.Context, userID uuid.UUID) ([]domain.Ban, error)
+	LiftBan(ctx context.Context, actorID uuid.UUID, banID uuid.UUID) error
+	RunExpirySweeper(ctx context.Context, interval time.Duration)
+}
+type Profile interface {
+	GetProfile(ctx context.Context, userID uuid.UUID) (domain.User, error)
+	UpdateRegion(ctx context.Context, userID uuid.UUID, region string) error
+	ListScores(ctx context.Context, filter domain.ScoreHistoryFilter) (domain.ScoreHistoryPage, error)
+}
+type Progress interface {
+	GetProgress(ctx context.Context, viewerID uuid.UUID, q domain.ProgressQuery) (domain.ProgressSeries, error)
+	RunSnapshotter(ctx context.Context, interval time.Duration)
+}
+type Health interface {
+	Ready(ctx context.Context) domain.HealthReport
+	Drain()
 }
 type Service struct {
 	Auth
 	ScoreHistory
 	Admin
 	Leaderboard
+	Profile
+	Moderation
+	Bans
+	Progress
+	Health
 }
 
+const (
+	// gameRegistryTTL bounds how long a replica may serve a game changed by another replica.
+	gameRegistryTTL = time.Minute
+	// banCacheTTL bounds how long a replica may miss a ban issued or lifted on another replica.
+	banCacheTTL = 30 * time.Second
+)
+
 func NewService(rep *repository.Repository, log *logger.SlogLogger, tokens auth.TokenManager) *Service {
+	registry := games.NewRegistry(rep.Admin, log, gameRegistryTTL)
+	checker := bans.NewChecker(rep.Bans, log, banCacheTTL)
 	return &Service{
-		Auth:         auth.NewServiceAuth(rep, log, tokens),
-		ScoreHistory: score_history.NewScoreService(rep, log),
-		Admin:        admin.NewServiceAdmin(rep, log),
-		Leaderboard:  leaderboard.NewServiceLeaderboard(rep, log),
+		Auth:         tracedAuth{auth.NewServiceAuth(rep, rep.Audit, log, tokens, checker)},
+		ScoreHistory: tracedScoreHistory{score_history.NewScoreService(rep, registry, checker, log)},
+		Admin:        tracedAdmin{admin.NewServiceAdmin(rep.Admin, rep, rep.Audit, rep.Auth, rep.ScoreHistory, registry, log)},
+		Leaderboard:  tracedLeaderboard{leaderboard.NewServiceLeaderboard(rep, rep, rep.Admin, log)},
+		Profile:      tracedProfile{profile.NewServiceProfile(rep, log)},
+		Moderation:   tracedModeration{moderation.NewServiceModeration(rep, registry, checker, log)},
+		Bans:         tracedBans{bans.NewServiceBans(rep, checker, registry, log)},
+		Progress:     tracedProgress{progress.NewServiceProgress(rep, registry, checker, log)},
+		// Probes are polled every few seconds and are left out of the traces.
+		Health: health.NewServiceHealth(rep.Health, migration.Latest(), log),
 	}
 }
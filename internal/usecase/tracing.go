@@ -0,0 +1,319 @@
+package usecase
+
+import (
+	"OnlineLeadership/internal/domain"
+	"context"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"time"
+)
+
+// The traced* types wrap the services so that every usecase call gets its own
+// span between the request span and the Postgres and Redis spans. Background
+// loops and the metrics scrape are passed through to keep them out of traces.
+
+var tracer = otel.Tracer("OnlineLeadership/internal/usecase")
+
+func start(ctx context.Context, name string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name)
+}
+
+func end(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+type tracedAuth struct{ next Auth }
+
+func (t tracedAuth) Register(ctx context.Context, user domain.User) (id uuid.UUID, err error) {
+	ctx, span := start(ctx, "Auth.Register")
+	defer func() { end(span, err) }()
+	return t.next.Register(ctx, user)
+}
+
+func (t tracedAuth) Login(ctx context.Context, username, password string) (access string, refresh string, err error) {
+	ctx, span := start(ctx, "Auth.Login")
+	defer func() { end(span, err) }()
+	return t.next.Login(ctx, username, password)
+}
+
+func (t tracedAuth) ParseRefreshToken(ctx context.Context, tokenR string) (userID string, err error) {
+	ctx, span := start(ctx, "Auth.ParseRefreshToken")
+	defer func() { end(span, err) }()
+	return t.next.ParseRefreshToken(ctx, tokenR)
+}
+
+func (t tracedAuth) ParseAccessToken(ctx context.Context, token string) (userID uuid.UUID, err error) {
+	ctx, span := start(ctx, "Auth.ParseAccessToken")
+	defer func() { end(span, err) }()
+	return t.next.ParseAccessToken(ctx, token)
+}
+
+func (t tracedAuth) GenerateAccessToken(userId string) (string, error) {
+	return t.next.GenerateAccessToken(userId)
+}
+
+type tracedScoreHistory struct{ next ScoreHistory }
+
+func (t tracedScoreHistory) SubmitScore(ctx context.Context, submission domain.ScoreSubmission) (err error) {
+	ctx, span := start(ctx, "ScoreHistory.SubmitScore")
+	defer func() { end(span, err) }()
+	return t.next.SubmitScore(ctx, submission)
+}
+
+func (t tracedScoreHistory) ListQuarantine(ctx context.Context, status string, offset int, limit int) (scores []domain.QuarantinedScore, err error) {
+	ctx, span := start(ctx, "ScoreHistory.ListQuarantine")
+	defer func() { end(span, err) }()
+	return t.next.ListQuarantine(ctx, status, offset, limit)
+}
+
+func (t tracedScoreHistory) ReleaseQuarantined(ctx context.Context, actorID uuid.UUID, id uuid.UUID) (err error) {
+	ctx, span := start(ctx, "ScoreHistory.ReleaseQuarantined")
+	defer func() { end(span, err) }()
+	return t.next.ReleaseQuarantined(ctx, actorID, id)
+}
+
+func (t tracedScoreHistory) DiscardQuarantined(ctx context.Context, actorID uuid.UUID, id uuid.UUID) (err error) {
+	ctx, span := start(ctx, "ScoreHistory.DiscardQuarantined")
+	defer func() { end(span, err) }()
+	return t.next.DiscardQuarantined(ctx, actorID, id)
+}
+
+type tracedAdmin struct{ next Admin }
+
+func (t tracedAdmin) Create(ctx context.Context, actorID uuid.UUID, game domain.Game) (id uuid.UUID, err error) {
+	ctx, span := start(ctx, "Admin.Create")
+	defer func() { end(span, err) }()
+	return t.next.Create(ctx, actorID, game)
+}
+
+func (t tracedAdmin) GetGames(ctx context.Context) (games []domain.Game, err error) {
+	ctx, span := start(ctx, "Admin.GetGames")
+	defer func() { end(span, err) }()
+	return t.next.GetGames(ctx)
+}
+
+func (t tracedAdmin) ListGames(ctx context.Context) (games []domain.Game, err error) {
+	ctx, span := start(ctx, "Admin.ListGames")
+	defer func() { end(span, err) }()
+	return t.next.ListGames(ctx)
+}
+
+func (t tracedAdmin) GetGame(ctx context.Context, id uuid.UUID) (game domain.Game, err error) {
+	ctx, span := start(ctx, "Admin.GetGame")
+	defer func() { end(span, err) }()
+	return t.next.GetGame(ctx, id)
+}
+
+func (t tracedAdmin) UpdateGame(ctx context.Context, actorID uuid.UUID, game domain.Game) (err error) {
+	ctx, span := start(ctx, "Admin.UpdateGame")
+	defer func() { end(span, err) }()
+	return t.next.UpdateGame(ctx, actorID, game)
+}
+
+func (t tracedAdmin) ArchiveGame(ctx context.Context, actorID uuid.UUID, id uuid.UUID) (err error) {
+	ctx, span := start(ctx, "Admin.ArchiveGame")
+	defer func() { end(span, err) }()
+	return t.next.ArchiveGame(ctx, actorID, id)
+}
+
+func (t tracedAdmin) RestoreGame(ctx context.Context, actorID uuid.UUID, id uuid.UUID) (err error) {
+	ctx, span := start(ctx, "Admin.RestoreGame")
+	defer func() { end(span, err) }()
+	return t.next.RestoreGame(ctx, actorID, id)
+}
+
+func (t tracedAdmin) RotateSigningSecret(ctx context.Context, actorID uuid.UUID, id uuid.UUID) (secret string, err error) {
+	ctx, span := start(ctx, "Admin.RotateSigningSecret")
+	defer func() { end(span, err) }()
+	return t.next.RotateSigningSecret(ctx, actorID, id)
+}
+
+func (t tracedAdmin) DisableSigning(ctx context.Context, actorID uuid.UUID, id uuid.UUID) (err error) {
+	ctx, span := start(ctx, "Admin.DisableSigning")
+	defer func() { end(span, err) }()
+	return t.next.DisableSigning(ctx, actorID, id)
+}
+
+func (t tracedAdmin) DeleteGame(ctx context.Context, actorID uuid.UUID, id uuid.UUID) (err error) {
+	ctx, span := start(ctx, "Admin.DeleteGame")
+	defer func() { end(span, err) }()
+	return t.next.DeleteGame(ctx, actorID, id)
+}
+
+func (t tracedAdmin) ListAudit(ctx context.Context, filter domain.AuditFilter) (page domain.AuditPage, err error) {
+	ctx, span := start(ctx, "Admin.ListAudit")
+	defer func() { end(span, err) }()
+	return t.next.ListAudit(ctx, filter)
+}
+
+func (t tracedAdmin) RebuildLeaderboard(ctx context.Context, actorID uuid.UUID, id uuid.UUID) (players int, err error) {
+	ctx, span := start(ctx, "Admin.RebuildLeaderboard")
+	defer func() { end(span, err) }()
+	return t.next.RebuildLeaderboard(ctx, actorID, id)
+}
+
+func (t tracedAdmin) FindUser(ctx context.Context, username string) (user domain.User, err error) {
+	ctx, span := start(ctx, "Admin.FindUser")
+	defer func() { end(span, err) }()
+	return t.next.FindUser(ctx, username)
+}
+
+func (t tracedAdmin) SetRole(ctx context.Context, actorID uuid.UUID, userID uuid.UUID, role string) (err error) {
+	ctx, span := start(ctx, "Admin.SetRole")
+	defer func() { end(span, err) }()
+	return t.next.SetRole(ctx, actorID, userID, role)
+}
+
+type tracedLeaderboard struct{ next Leaderboard }
+
+func (t tracedLeaderboard) GetGlobalLeaderboard(ctx context.Context, region string, page domain.PageRequest) (res domain.LeaderboardPage, err error) {
+	ctx, span := start(ctx, "Leaderboard.GetGlobalLeaderboard")
+	defer func() { end(span, err) }()
+	return t.next.GetGlobalLeaderboard(ctx, region, page)
+}
+
+func (t tracedLeaderboard) GetLeaderboard(ctx context.Context, gameID uuid.UUID, region string, page domain.PageRequest) (res domain.LeaderboardPage, err error) {
+	ctx, span := start(ctx, "Leaderboard.GetLeaderboard")
+	defer func() { end(span, err) }()
+	return t.next.GetLeaderboard(ctx, gameID, region, page)
+}
+
+func (t tracedLeaderboard) GetMyRank(ctx context.Context, userID uuid.UUID) (rank domain.PlayerRank, err error) {
+	ctx, span := start(ctx, "Leaderboard.GetMyRank")
+	defer func() { end(span, err) }()
+	return t.next.GetMyRank(ctx, userID)
+}
+
+func (t tracedLeaderboard) GetLeaderboardVersion(ctx context.Context, gameID uuid.UUID) (version domain.BoardVersion, err error) {
+	ctx, span := start(ctx, "Leaderboard.GetLeaderboardVersion")
+	defer func() { end(span, err) }()
+	return t.next.GetLeaderboardVersion(ctx, gameID)
+}
+
+func (t tracedLeaderboard) GetGameRank(ctx context.Context, userID uuid.UUID, gameID uuid.UUID) (rank domain.PlayerRank, err error) {
+	ctx, span := start(ctx, "Leaderboard.GetGameRank")
+	defer func() { end(span, err) }()
+	return t.next.GetGameRank(ctx, userID, gameID)
+}
+
+func (t tracedLeaderboard) GetDistribution(ctx context.Context, gameID uuid.UUID, region string, buckets int) (dist domain.ScoreDistribution, err error) {
+	ctx, span := start(ctx, "Leaderboard.GetDistribution")
+	defer func() { end(span, err) }()
+	return t.next.GetDistribution(ctx, gameID, region, buckets)
+}
+
+func (t tracedLeaderboard) BoardSizes(ctx context.Context) (map[string]int64, error) {
+	return t.next.BoardSizes(ctx)
+}
+
+type tracedModeration struct{ next Moderation }
+
+func (t tracedModeration) FlagScore(ctx context.Context, reporterID uuid.UUID, scoreID uuid.UUID, reason string) (id uuid.UUID, err error) {
+	ctx, span := start(ctx, "Moderation.FlagScore")
+	defer func() { end(span, err) }()
+	return t.next.FlagScore(ctx, reporterID, scoreID, reason)
+}
+
+func (t tracedModeration) ListFlags(ctx context.Context, status string, offset int, limit int) (flags []domain.ScoreFlag, err error) {
+	ctx, span := start(ctx, "Moderation.ListFlags")
+	defer func() { end(span, err) }()
+	return t.next.ListFlags(ctx, status, offset, limit)
+}
+
+func (t tracedModeration) ApproveFlag(ctx context.Context, id uuid.UUID, moderatorID uuid.UUID) (err error) {
+	ctx, span := start(ctx, "Moderation.ApproveFlag")
+	defer func() { end(span, err) }()
+	return t.next.ApproveFlag(ctx, id, moderatorID)
+}
+
+func (t tracedModeration) RejectFlag(ctx context.Context, id uuid.UUID, moderatorID uuid.UUID) (err error) {
+	ctx, span := start(ctx, "Moderation.RejectFlag")
+	defer func() { end(span, err) }()
+	return t.next.RejectFlag(ctx, id, moderatorID)
+}
+
+func (t tracedModeration) VoidScore(ctx context.Context, actorID uuid.UUID, scoreID uuid.UUID, reason string) (err error) {
+	ctx, span := start(ctx, "Moderation.VoidScore")
+	defer func() { end(span, err) }()
+	return t.next.VoidScore(ctx, actorID, scoreID, reason)
+}
+
+func (t tracedModeration) AdjustScore(ctx context.Context, actorID uuid.UUID, gameID uuid.UUID, userID uuid.UUID, delta int, reason string) (err error) {
+	ctx, span := start(ctx, "Moderation.AdjustScore")
+	defer func() { end(span, err) }()
+	return t.next.AdjustScore(ctx, actorID, gameID, userID, delta, reason)
+}
+
+func (t tracedModeration) RemoveUser(ctx context.Context, actorID uuid.UUID, userID uuid.UUID, gameID *uuid.UUID, reason string) (err error) {
+	ctx, span := start(ctx, "Moderation.RemoveUser")
+	defer func() { end(span, err) }()
+	return t.next.RemoveUser(ctx, actorID, userID, gameID, reason)
+}
+
+type tracedBans struct{ next Bans }
+
+func (t tracedBans) CheckBan(ctx context.Context, userID uuid.UUID, scope string) (err error) {
+	ctx, span := start(ctx, "Bans.CheckBan")
+	defer func() { end(span, err) }()
+	return t.next.CheckBan(ctx, userID, scope)
+}
+
+func (t tracedBans) Ban(ctx context.Context, actorID uuid.UUID, userID uuid.UUID, scopes []string, reason string, duration time.Duration) (id uuid.UUID, err error) {
+	ctx, span := start(ctx, "Bans.Ban")
+	defer func() { end(span, err) }()
+	return t.next.Ban(ctx, actorID, userID, scopes, reason, duration)
+}
+
+func (t tracedBans) ListBans(ctx context.Context, userID uuid.UUID) (bans []domain.Ban, err error) {
+	ctx, span := start(ctx, "Bans.ListBans")
+	defer func() { end(span, err) }()
+	return t.next.ListBans(ctx, userID)
+}
+
+func (t tracedBans) LiftBan(ctx context.Context, actorID uuid.UUID, banID uuid.UUID) (err error) {
+	ctx, span := start(ctx, "Bans.LiftBan")
+	defer func() { end(span, err) }()
+	return t.next.LiftBan(ctx, actorID, banID)
+}
+
+func (t tracedBans) RunExpirySweeper(ctx context.Context, interval time.Duration) {
+	t.next.RunExpirySweeper(ctx, interval)
+}
+
+type tracedProfile struct{ next Profile }
+
+func (t tracedProfile) GetProfile(ctx context.Context, userID uuid.UUID) (user domain.User, err error) {
+	ctx, span := start(ctx, "Profile.GetProfile")
+	defer func() { end(span, err) }()
+	return t.next.GetProfile(ctx, userID)
+}
+
+func (t tracedProfile) UpdateRegion(ctx context.Context, userID uuid.UUID, region string) (err error) {
+	ctx, span := start(ctx, "Profile.UpdateRegion")
+	defer func() { end(span, err) }()
+	return t.next.UpdateRegion(ctx, userID, region)
+}
+
+func (t tracedProfile) ListScores(ctx context.Context, filter domain.ScoreHistoryFilter) (page domain.ScoreHistoryPage, err error) {
+	ctx, span := start(ctx, "Profile.ListScores")
+	defer func() { end(span, err) }()
+	return t.next.ListScores(ctx, filter)
+}
+
+type tracedProgress struct{ next Progress }
+
+func (t tracedProgress) GetProgress(ctx context.Context, viewerID uuid.UUID, q domain.ProgressQuery) (series domain.ProgressSeries, err error) {
+	ctx, span := start(ctx, "Progress.GetProgress")
+	defer func() { end(span, err) }()
+	return t.next.GetProgress(ctx, viewerID, q)
+}
+
+func (t tracedProgress) RunSnapshotter(ctx context.Context, interval time.Duration) {
+	t.next.RunSnapshotter(ctx, interval)
+}
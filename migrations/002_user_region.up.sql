@@ -0,0 +1,3 @@
+ALTER TABLE users ADD COLUMN region TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX idx_users_region ON users(region);
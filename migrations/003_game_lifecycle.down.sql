@@ -0,0 +1,9 @@
+ALTER TABLE users DROP COLUMN IF EXISTS role;
+DROP INDEX IF EXISTS idx_games_slug;
+ALTER TABLE games
+    DROP COLUMN IF EXISTS slug,
+    DROP COLUMN IF EXISTS description,
+    DROP COLUMN IF EXISTS config,
+    DROP COLUMN IF EXISTS archived_at,
+    DROP COLUMN IF EXISTS created_at,
+    DROP COLUMN IF EXISTS updated_at;
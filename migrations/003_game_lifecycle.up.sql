@@ -0,0 +1,20 @@
+ALTER TABLE games
+    ADD COLUMN slug TEXT,
+    ADD COLUMN description TEXT NOT NULL DEFAULT '',
+    ADD COLUMN config JSONB NOT NULL DEFAULT '{}'::jsonb,
+    ADD COLUMN archived_at TIMESTAMP,
+    ADD COLUMN created_at TIMESTAMP NOT NULL DEFAULT now(),
+    ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT now();
+
+-- Backfill slugs from names; fall back to the id when a name has no usable characters
+-- and disambiguate names that collapse to the same slug.
+UPDATE games SET slug = trim(both '-' from regexp_replace(lower(name), '[^a-z0-9]+', '-', 'g'));
+UPDATE games SET slug = id::text WHERE slug = '';
+UPDATE games g SET slug = g.slug || '-' || left(g.id::text, 8)
+WHERE EXISTS (SELECT 1 FROM games o WHERE o.slug = g.slug AND o.id <> g.id);
+
+ALTER TABLE games ALTER COLUMN slug SET NOT NULL;
+CREATE UNIQUE INDEX idx_games_slug ON games(slug);
+
+-- Game administration requires the admin role.
+ALTER TABLE users ADD COLUMN role TEXT NOT NULL DEFAULT 'player';
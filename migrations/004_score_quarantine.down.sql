@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_score_game_created;
+DROP INDEX IF EXISTS idx_score_user_game_created;
+DROP TABLE IF EXISTS score_quarantine;
@@ -0,0 +1,18 @@
+CREATE TABLE score_quarantine (
+                                  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                  user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+                                  game_id UUID REFERENCES games(id) ON DELETE CASCADE,
+                                  score INT NOT NULL,
+                                  rule TEXT NOT NULL,
+                                  reason TEXT NOT NULL,
+                                  status TEXT NOT NULL DEFAULT 'pending',
+                                  created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_quarantine_status ON score_quarantine(status, created_at);
+CREATE INDEX idx_quarantine_user_game ON score_quarantine(user_id, game_id, created_at);
+
+-- Anti-cheat rules count and sum a player's recent submissions per game
+-- and sample a game's most recent submissions.
+CREATE INDEX idx_score_user_game_created ON score_history(user_id, game_id, created_at);
+CREATE INDEX idx_score_game_created ON score_history(game_id, created_at);
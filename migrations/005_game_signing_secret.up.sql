@@ -0,0 +1,2 @@
+-- Games with a signing secret only accept score submissions signed with it.
+ALTER TABLE games ADD COLUMN signing_secret TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS score_flags;
+ALTER TABLE score_history DROP COLUMN IF EXISTS status;
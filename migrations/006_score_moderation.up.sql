@@ -0,0 +1,19 @@
+-- Rejected scores stay in the history for the record but no longer count.
+ALTER TABLE score_history ADD COLUMN status TEXT NOT NULL DEFAULT 'accepted';
+
+CREATE TABLE score_flags (
+                             id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                             score_id UUID NOT NULL REFERENCES score_history(id) ON DELETE CASCADE,
+                             reporter_id UUID REFERENCES users(id) ON DELETE SET NULL,
+                             source TEXT NOT NULL,
+                             reason TEXT NOT NULL,
+                             status TEXT NOT NULL DEFAULT 'pending',
+                             moderator_id UUID REFERENCES users(id) ON DELETE SET NULL,
+                             decided_at TIMESTAMP,
+                             created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_flags_status ON score_flags(status, created_at);
+CREATE INDEX idx_flags_score ON score_flags(score_id);
+-- A player reports a score at most once.
+CREATE UNIQUE INDEX idx_flags_score_reporter ON score_flags(score_id, reporter_id);
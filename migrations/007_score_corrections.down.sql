@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS audit_log;
+ALTER TABLE score_history DROP COLUMN IF EXISTS kind;
@@ -0,0 +1,16 @@
+-- Adjustments are admin corrections stored next to submissions; they count
+-- towards the board but not towards the anti-cheat statistics.
+ALTER TABLE score_history ADD COLUMN kind TEXT NOT NULL DEFAULT 'submission';
+
+CREATE TABLE audit_log (
+                           id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                           actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+                           action TEXT NOT NULL,
+                           target_type TEXT NOT NULL,
+                           target_id UUID NOT NULL,
+                           details JSONB NOT NULL DEFAULT '{}'::jsonb,
+                           created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_audit_created ON audit_log(created_at);
+CREATE INDEX idx_audit_target ON audit_log(target_type, target_id);
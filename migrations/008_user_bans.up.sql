@@ -0,0 +1,15 @@
+CREATE TABLE bans (
+                      id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                      user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                      scopes TEXT[] NOT NULL,
+                      reason TEXT NOT NULL,
+                      expires_at TIMESTAMP,
+                      created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+                      created_at TIMESTAMP NOT NULL DEFAULT now(),
+                      lifted_at TIMESTAMP,
+                      lifted_by UUID REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_bans_user ON bans(user_id, created_at);
+-- Active bans are loaded in full and swept on expiry.
+CREATE INDEX idx_bans_unlifted ON bans(expires_at) WHERE lifted_at IS NULL;
@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS idx_audit_action;
+DROP INDEX IF EXISTS idx_audit_actor;
+
+DROP TRIGGER IF EXISTS audit_log_append_only ON audit_log;
+DROP FUNCTION IF EXISTS audit_log_append_only();
+
+ALTER TABLE audit_log DROP COLUMN IF EXISTS after;
+ALTER TABLE audit_log DROP COLUMN IF EXISTS before;
+ALTER TABLE audit_log DROP COLUMN IF EXISTS ip;
+ALTER TABLE audit_log DROP COLUMN IF EXISTS request_id;
+DELETE FROM audit_log WHERE target_id IS NULL;
+ALTER TABLE audit_log ALTER COLUMN target_id SET NOT NULL;
+UPDATE audit_log SET actor_id = NULL WHERE actor_id NOT IN (SELECT id FROM users);
+ALTER TABLE audit_log ADD CONSTRAINT audit_log_actor_id_fkey FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL;
@@ -0,0 +1,21 @@
+-- The audit log is append-only: the actor reference is kept as a plain id so
+-- deleting a user never rewrites history, and a trigger rejects changes.
+ALTER TABLE audit_log DROP CONSTRAINT IF EXISTS audit_log_actor_id_fkey;
+ALTER TABLE audit_log ALTER COLUMN target_id DROP NOT NULL;
+ALTER TABLE audit_log ADD COLUMN request_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE audit_log ADD COLUMN ip TEXT NOT NULL DEFAULT '';
+ALTER TABLE audit_log ADD COLUMN before JSONB;
+ALTER TABLE audit_log ADD COLUMN after JSONB;
+
+CREATE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_append_only
+    BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
+
+CREATE INDEX idx_audit_actor ON audit_log(actor_id, created_at);
+CREATE INDEX idx_audit_action ON audit_log(action, created_at);
@@ -0,0 +1,13 @@
+-- Periodic snapshots of each player's rank and score on every game board,
+-- one row per player, game and snapshot interval.
+CREATE TABLE rank_snapshots (
+                                user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                game_id UUID NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+                                taken_at TIMESTAMP NOT NULL,
+                                rank BIGINT NOT NULL,
+                                score BIGINT NOT NULL,
+                                PRIMARY KEY (user_id, game_id, taken_at)
+);
+
+-- Retention deletes by age.
+CREATE INDEX idx_rank_snapshots_taken ON rank_snapshots(taken_at);
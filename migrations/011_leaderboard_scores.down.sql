@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS leaderboard_versions;
+DROP TABLE IF EXISTS leaderboard_scores;
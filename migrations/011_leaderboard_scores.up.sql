@@ -0,0 +1,21 @@
+-- Leaderboards for leaderboard.store = postgres: one row per player and board,
+-- maintained on every submit. board is 'global' or a game id; region is empty
+-- for the overall board. Members compare byte-wise, as in Redis, so that ties
+-- are ordered the same way by every store.
+CREATE TABLE leaderboard_scores (
+                                    board TEXT NOT NULL,
+                                    region TEXT NOT NULL DEFAULT '',
+                                    member TEXT COLLATE "C" NOT NULL,
+                                    score BIGINT NOT NULL,
+                                    PRIMARY KEY (board, region, member)
+);
+
+-- Top-N pages, cursors and rank lookups walk a board in rank order.
+CREATE INDEX idx_leaderboard_scores_rank ON leaderboard_scores(board, region, score DESC, member DESC);
+
+-- Version counter of each game board, for ETag and Last-Modified.
+CREATE TABLE leaderboard_versions (
+                                      game_id TEXT PRIMARY KEY,
+                                      version BIGINT NOT NULL,
+                                      updated_at TIMESTAMP NOT NULL
+);
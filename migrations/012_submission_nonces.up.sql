@@ -0,0 +1,11 @@
+-- Nonces of signed score submissions when Redis is not configured: a nonce is
+-- taken until expires_at and may be claimed again afterwards.
+CREATE TABLE submission_nonces (
+                                   game_id UUID NOT NULL,
+                                   nonce TEXT NOT NULL,
+                                   expires_at TIMESTAMP NOT NULL,
+                                   PRIMARY KEY (game_id, nonce)
+);
+
+-- Expired nonces are deleted by age.
+CREATE INDEX idx_submission_nonces_expires ON submission_nonces(expires_at);
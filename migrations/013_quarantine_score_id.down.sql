@@ -0,0 +1 @@
+ALTER TABLE score_quarantine DROP COLUMN IF EXISTS score_id;
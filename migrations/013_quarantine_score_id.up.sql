@@ -0,0 +1,3 @@
+-- A released submission points at the score history entry it became, so that
+-- a release is recorded once however often it is retried.
+ALTER TABLE score_quarantine ADD COLUMN score_id UUID REFERENCES score_history(id) ON DELETE SET NULL;
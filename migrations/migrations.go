@@ -0,0 +1,8 @@
+// Package migrations embeds the SQL migrations so that the binary can apply
+// them itself; see the migrate subcommand.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS